@@ -0,0 +1,167 @@
+package slog
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxAggregateSamples bounds the number of values of the numeric field
+// an aggregator keeps per window to compute percentiles.
+const maxAggregateSamples = 10000
+
+// Aggregate makes the Logger summarize the events with the message msg
+// instead of writing them, which turns high-volume logs, such as
+// per-request debug logs, into cheap summaries. Once every window, the
+// Logger writes one event with the message msg, the level of the last
+// event, and the "count" of events in the window as fields. If field
+// is not empty, it also writes the "<field>_p50" and "<field>_p95"
+// percentiles of the numeric values of field.
+//
+// A summary is written when the first event after its window is
+// logged, and when the Logger is closed.
+//
+// If window is not positive, the events with the message msg
+// are no longer aggregated, which is the default.
+func (l *Logger) Aggregate(msg, field string, window time.Duration) {
+	l.mu.Lock()
+	old := describeKeys(aggregateKeys(l.cfg.aggregates))
+
+	aggregates := make(map[string]*aggregator, len(l.cfg.aggregates)+1)
+	for k, a := range l.cfg.aggregates {
+		aggregates[k] = a
+	}
+
+	if window <= 0 {
+		delete(aggregates, msg)
+	} else {
+		aggregates[msg] = &aggregator{field: field, window: window}
+	}
+
+	if len(aggregates) == 0 {
+		aggregates = nil
+	}
+	l.cfg.aggregates = aggregates
+
+	new := describeKeys(aggregateKeys(l.cfg.aggregates))
+	l.mu.Unlock()
+
+	l.configChanged("aggregates", old, new)
+}
+
+// aggregateKeys returns the sorted messages of aggregates,
+// or nil if aggregates is nil.
+func aggregateKeys(aggregates map[string]*aggregator) []string {
+	if aggregates == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(aggregates))
+	for k := range aggregates {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+type aggregator struct {
+	field  string
+	window time.Duration
+
+	mu     sync.Mutex
+	start  time.Time
+	last   *Event
+	count  int
+	values []float64
+}
+
+// add adds e to the window, and returns the summary of the previous
+// window if e is after it.
+func (a *aggregator) add(e *Event) *Event {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var s *Event
+	if a.count > 0 && e.Time.Sub(a.start) >= a.window {
+		s = a.summarize()
+	}
+
+	if a.count == 0 {
+		a.start = e.Time
+	}
+
+	a.count++
+	a.last = e
+
+	if a.field != "" && len(a.values) < maxAggregateSamples {
+		if v, ok := e.Fields[a.field].(string); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				a.values = append(a.values, f)
+			}
+		}
+	}
+
+	return s
+}
+
+// flush returns the summary of the current window, if it has events.
+func (a *aggregator) flush() *Event {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.count == 0 {
+		return nil
+	}
+
+	return a.summarize()
+}
+
+// summarize returns the summary of the window and starts a new one.
+func (a *aggregator) summarize() *Event {
+	f := Fields{"count": strconv.Itoa(a.count)}
+
+	if len(a.values) > 0 {
+		sort.Float64s(a.values)
+		f[a.field+"_p50"] = formatValue(percentile(a.values, 50))
+		f[a.field+"_p95"] = formatValue(percentile(a.values, 95))
+	}
+
+	s := &Event{
+		Level:   a.last.Level,
+		File:    a.last.File,
+		Time:    a.last.Time,
+		Fields:  f,
+		Message: a.last.Message,
+	}
+
+	a.count = 0
+	a.last = nil
+	a.values = a.values[:0]
+
+	return s
+}
+
+// percentile returns the nearest-rank p-th percentile
+// of the sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+
+	return sorted[i]
+}
+
+// flushAggregates writes the summaries of the current windows.
+func (l *Logger) flushAggregates() {
+	cfg := l.getConfig()
+
+	for _, k := range aggregateKeys(cfg.aggregates) {
+		if s := cfg.aggregates[k].flush(); s != nil {
+			l.write(s)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package slog
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w), WithDeterministic(func() time.Time { return now }))
+	l.Aggregate("request", "duration_ms", time.Minute)
+
+	for i := 1; i <= 20; i++ {
+		l.Debugf(Fields{"duration_ms": i}, "request")
+		now = now.Add(time.Second)
+	}
+	l.Info("not aggregated")
+
+	// The first request after the window writes the summary.
+	now = now.Add(time.Minute)
+	l.Debugf(Fields{"duration_ms": "oops"}, "request")
+
+	l.Close()
+
+	exp := []struct {
+		msg    string
+		fields map[string]string
+	}{
+		{msg: "not aggregated"},
+		{msg: "request", fields: map[string]string{"count": "20", "duration_ms_p50": "10", "duration_ms_p95": "19"}},
+		{msg: "request", fields: map[string]string{"count": "1"}},
+	}
+
+	if len(w.lines) != len(exp) {
+		t.Fatalf("expected '%d' line(s), got '%d': %v", len(exp), len(w.lines), w.lines)
+	}
+
+	for i, line := range w.lines {
+		var e struct {
+			Metadata map[string]string `json:"_metadata"`
+			Fields   map[string]string `json:"fields"`
+			Message  string            `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Message != exp[i].msg || !reflect.DeepEqual(e.Fields, exp[i].fields) {
+			t.Fatalf("expected message '%s' with fields '%v', got '%s'", exp[i].msg, exp[i].fields, line)
+		}
+	}
+}
+
+func TestAggregateOff(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w))
+	l.Aggregate("request", "", time.Minute)
+	l.Aggregate("request", "", 0)
+
+	l.Info("request")
+	if len(w.lines) != 1 {
+		t.Fatalf("expected '%d' line(s), got '%d'", 1, len(w.lines))
+	}
+}
@@ -0,0 +1,167 @@
+package slog
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// maxAnnotatable bounds the number of events a Logger remembers
+// for Annotate, so memory stays bounded under heavy logging.
+const maxAnnotatable = 10000
+
+// annotationMessage is the message of annotation events.
+const annotationMessage = "annotation"
+
+// ErrUnknownEvent is returned by Annotate for an event ID that is not
+// in the Logger's correlation cache.
+var ErrUnknownEvent = errors.New("slog: unknown or expired event ID")
+
+// SetAnnotationTTL makes the Logger remember the events it logs with an
+// ID, set with SetEventID, for ttl in a correlation cache, so that they
+// can be annotated after the fact with Annotate. Only the 10000 most
+// recent events are remembered.
+//
+// If ttl is not positive, events are not remembered, which is the default.
+func (l *Logger) SetAnnotationTTL(ttl time.Duration) {
+	l.mu.Lock()
+	old := l.cfg.annotations.String()
+
+	if ttl <= 0 {
+		l.cfg.annotations = nil
+	} else {
+		l.cfg.annotations = &correlationCache{
+			ttl:    ttl,
+			events: make(map[string]Level),
+		}
+	}
+
+	new := l.cfg.annotations.String()
+	l.mu.Unlock()
+
+	l.configChanged("annotation_ttl", old, new)
+}
+
+// Annotate logs an annotation of the event with the given ID, for
+// example from a Hook of a later stage that learns how a failure
+// was resolved:
+//
+//	l.Annotate(id, slog.Fields{"resolution": "retried"})
+//
+// The annotation is a follow-up event with the level of the original
+// event, the message "annotation", the fields f, and the ID of the
+// original under "annotates" in its metadata. Hooks do not fire for
+// annotations, so Hooks may call Annotate.
+//
+// Annotate returns ErrUnknownEvent if the event is not in the
+// correlation cache, because it was logged before the TTL set with
+// SetAnnotationTTL, or because annotations are off.
+func (l *Logger) Annotate(id string, f Fields) error {
+	cfg := l.getConfig()
+
+	lv, ok := cfg.annotations.lookup(id, l.now())
+	if !ok {
+		return ErrUnknownEvent
+	}
+
+	// Annotate is one frame shallower than the logging methods,
+	// which call fileInfo through log and logEvent.
+	file, _ := l.fileInfo(-1)
+
+	fields := make(Fields, len(f))
+	for k, v := range resolveFields(f) {
+		fields[k] = formatField(v)
+	}
+
+	ev := &Event{
+		Level:    lv,
+		File:     file,
+		Time:     l.now(),
+		Fields:   fields,
+		Message:  annotationMessage,
+		Metadata: Fields{l.keyNames().Annotates: id},
+	}
+
+	if cfg.idGen != nil {
+		ev.ID = cfg.idGen.NewID()
+	}
+
+	cfg.addSeverity(ev)
+
+	l.record(ev)
+	l.write(ev)
+
+	return nil
+}
+
+// String describes the TTL of c, or returns "off" if c is nil.
+func (c *correlationCache) String() string {
+	if c == nil {
+		return "off"
+	}
+
+	return c.ttl.String()
+}
+
+// correlationCache remembers the levels of recently logged events
+// by ID, for Annotate.
+type correlationCache struct {
+	ttl time.Duration
+
+	mu     sync.Mutex
+	events map[string]Level
+	// order holds the remembered events in the order they were
+	// logged, so the oldest expire first.
+	order []remembered
+}
+
+type remembered struct {
+	id      string
+	expires time.Time
+}
+
+// remember adds e to c, if c is non-nil and e has an ID.
+func (c *correlationCache) remember(e *Event) {
+	if c == nil || e.ID == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire(e.Time)
+
+	if len(c.order) == maxAnnotatable {
+		delete(c.events, c.order[0].id)
+		c.order = c.order[1:]
+	}
+
+	c.events[e.ID] = e.Level
+	c.order = append(c.order, remembered{id: e.ID, expires: e.Time.Add(c.ttl)})
+}
+
+// lookup returns the level of the event with the given ID,
+// if it has not expired by now.
+func (c *correlationCache) lookup(id string, now time.Time) (Level, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire(now)
+
+	lv, ok := c.events[id]
+	return lv, ok
+}
+
+// expire forgets the events that expired by now.
+func (c *correlationCache) expire(now time.Time) {
+	i := 0
+	for ; i < len(c.order) && !now.Before(c.order[i].expires); i++ {
+		delete(c.events, c.order[i].id)
+	}
+
+	c.order = c.order[i:]
+}
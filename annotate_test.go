@@ -0,0 +1,140 @@
+package slog
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnnotate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		ids     bool
+		advance time.Duration
+		id      string
+		err     error
+	}{
+		{name: "annotated", ttl: time.Minute, ids: true, id: "1"},
+		{name: "unknown", ttl: time.Minute, ids: true, id: "2", err: ErrUnknownEvent},
+		{name: "expired", ttl: time.Minute, ids: true, advance: time.Minute, id: "1", err: ErrUnknownEvent},
+		{name: "off", ids: true, id: "1", err: ErrUnknownEvent},
+		{name: "without ids", ttl: time.Minute, id: "", err: ErrUnknownEvent},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			w := &linesWriter{}
+			l := NewLogger(WithOutput(w), WithClock(func() time.Time { return now }))
+			l.SetAnnotationTTL(test.ttl)
+			if test.ids {
+				l.SetEventID(&SequenceGenerator{})
+			}
+
+			l.Warn("upstream failed")
+			now = now.Add(test.advance)
+
+			err := l.Annotate(test.id, Fields{"resolution": "retried"})
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error '%v', got '%v'", test.err, err)
+			}
+
+			if test.err != nil {
+				if len(w.lines) != 1 {
+					t.Fatalf("expected '%d' event, got '%d'", 1, len(w.lines))
+				}
+				return
+			}
+
+			var e event
+			if err := json.Unmarshal([]byte(w.lines[1]), &e); err != nil {
+				t.Fatal(err)
+			}
+
+			if e.Message != annotationMessage ||
+				e.Metadata["level"] != string(WarnLevel) ||
+				e.Metadata["annotates"] != test.id ||
+				e.Metadata["event_id"] != "2" ||
+				e.Fields["resolution"] != "retried" {
+				t.Fatalf("expected an annotation of '%s', got '%s'", test.id, w.lines[1])
+			}
+
+			if file, _ := e.Metadata["file"].(string); !strings.HasPrefix(file, "annotate_test.go:") {
+				t.Fatalf("expected the file of the caller, got '%s'", file)
+			}
+		})
+	}
+}
+
+func TestAnnotateFromHook(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w), WithKeys(Keys{Annotates: "ref"}))
+	l.SetEventID(&SequenceGenerator{})
+	l.SetAnnotationTTL(time.Minute)
+
+	var failed string
+	l.AddHook(HookFunc(func(e *Event) {
+		switch e.Message {
+		case "request failed":
+			failed = e.ID
+		case "retry succeeded":
+			if err := l.Annotate(failed, Fields{"resolution": "retried"}); err != nil {
+				t.Error(err)
+			}
+		}
+	}))
+
+	l.Error("request failed")
+	l.Info("retry succeeded")
+
+	if len(w.lines) != 3 {
+		t.Fatalf("expected '%d' events, got '%d'", 3, len(w.lines))
+	}
+
+	var e event
+	if err := json.Unmarshal([]byte(w.lines[1]), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Metadata["ref"] != "1" || e.Metadata["level"] != string(ErrorLevel) {
+		t.Fatalf("expected an annotation of '%s' at level '%s', got '%s'", "1", ErrorLevel, w.lines[1])
+	}
+}
+
+func TestCorrelationCacheBound(t *testing.T) {
+	t.Parallel()
+
+	var (
+		c   = &correlationCache{ttl: time.Hour, events: make(map[string]Level)}
+		now = time.Now()
+	)
+
+	for i := 0; i <= maxAnnotatable; i++ {
+		c.remember(&Event{ID: strconv.Itoa(i), Level: InfoLevel, Time: now})
+	}
+
+	if _, ok := c.lookup("0", now); ok {
+		t.Fatal("expected the oldest event to be forgotten, but it was not")
+	}
+
+	if _, ok := c.lookup(strconv.Itoa(maxAnnotatable), now); !ok {
+		t.Fatal("expected the newest event to be remembered, but it was not")
+	}
+
+	if len(c.events) != maxAnnotatable {
+		t.Fatalf("expected '%d' events, got '%d'", maxAnnotatable, len(c.events))
+	}
+}
@@ -0,0 +1,248 @@
+// Package anonymize rewrites archives of slog events with irreversible
+// anonymization rules, producing logs that can be shared with vendors
+// or support without leaking customer data.
+package anonymize
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/safe-waters/slog"
+)
+
+// Rules describe how fields are anonymized. Rules apply to the fields
+// of events, not to their metadata or message. A key named by more than
+// one rule is dropped if Drop names it, and otherwise hashed.
+//
+// Fields in groups are matched by their key, such as "email", or by
+// their path of keys joined with dots, such as "user.email", which
+// takes priority. A rule that matches a group applies to the group
+// as a whole.
+type Rules struct {
+	// Drop names the fields that are removed.
+	Drop []string
+
+	// Hash names the fields whose values are replaced with a keyed
+	// hash, so that equal values can still be correlated.
+	Hash []string
+
+	// HashKey is the secret key of the hash. If it is empty, a random
+	// key is used, so hashes cannot be correlated across calls to Copy.
+	// Hashes can only be reversed by guessing values with the key,
+	// so a HashKey that is given out makes them reversible.
+	HashKey []byte
+
+	// IPs names the fields whose IP addresses are generalized to their
+	// network: /24 for IPv4 and /48 for IPv6. Values that are not IP
+	// addresses are dropped.
+	IPs []string
+
+	// Keys are the keys the events were encoded with, as set with
+	// slog.WithKeys. Empty names keep their defaults.
+	Keys slog.Keys
+
+	// Flat is true for events encoded with slog.WithFlat. Their
+	// top-level keys are taken to be fields, except for the message
+	// and the metadata named by Keys, and fields prefixed with
+	// "fields." are matched without the prefix. Other metadata, such
+	// as the severity, cannot be told apart from fields.
+	Flat bool
+}
+
+// Copy reads events, one per line as written by slog, from src,
+// anonymizes them with rules, and writes them to dst. Empty lines
+// are skipped. It returns an error if a line is not an event.
+func Copy(dst io.Writer, src io.Reader, rules Rules) error {
+	a, err := newAnonymizer(rules)
+	if err != nil {
+		return err
+	}
+
+	var (
+		r = bufio.NewReader(src)
+		w = bufio.NewWriter(dst)
+	)
+
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("anonymize: %w", err)
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			out, aErr := a.anonymize(line)
+			if aErr != nil {
+				return fmt.Errorf("anonymize: line %d: %w", n, aErr)
+			}
+
+			w.Write(out)
+			w.WriteByte('\n')
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("anonymize: %w", err)
+	}
+
+	return nil
+}
+
+type action int
+
+const (
+	drop action = iota + 1
+	hash
+	generalizeIP
+)
+
+type anonymizer struct {
+	key     []byte
+	actions map[string]action
+	keys    slog.Keys
+	flat    bool
+}
+
+func newAnonymizer(rules Rules) (*anonymizer, error) {
+	a := &anonymizer{
+		key:     rules.HashKey,
+		actions: make(map[string]action),
+		keys:    rules.Keys.WithDefaults(),
+		flat:    rules.Flat,
+	}
+
+	if len(a.key) == 0 {
+		a.key = make([]byte, 32)
+		if _, err := rand.Read(a.key); err != nil {
+			return nil, fmt.Errorf("anonymize: %w", err)
+		}
+	}
+
+	for _, k := range rules.IPs {
+		a.actions[k] = generalizeIP
+	}
+	for _, k := range rules.Hash {
+		a.actions[k] = hash
+	}
+	for _, k := range rules.Drop {
+		a.actions[k] = drop
+	}
+
+	return a, nil
+}
+
+func (a *anonymizer) anonymize(line []byte) ([]byte, error) {
+	var e map[string]interface{}
+	if err := json.Unmarshal(line, &e); err != nil {
+		return nil, err
+	}
+
+	if a.flat {
+		a.anonymizeFlat(e)
+	} else if fields, ok := e[a.keys.Fields].(map[string]interface{}); ok {
+		a.anonymizeFields(fields, "")
+	}
+
+	return json.Marshal(e)
+}
+
+// anonymizeFields applies the rules to the fields of group,
+// whose path of keys is prefix, and to those of its groups.
+func (a *anonymizer) anonymizeFields(group map[string]interface{}, prefix string) {
+	for k, v := range group {
+		if !a.apply(group, k, k, prefix+k, v) {
+			if g, ok := v.(map[string]interface{}); ok {
+				a.anonymizeFields(g, prefix+k+".")
+			}
+		}
+	}
+}
+
+// anonymizeFlat applies the rules to the fields of the flat event e,
+// skipping its message and metadata.
+func (a *anonymizer) anonymizeFlat(e map[string]interface{}) {
+	meta := map[string]bool{a.keys.Message: true}
+	for _, k := range []string{
+		a.keys.Level, a.keys.Time, a.keys.File, a.keys.Function,
+		a.keys.EventID, a.keys.Fingerprint, a.keys.Logger,
+		a.keys.Goroutine, a.keys.Seq, a.keys.Annotates,
+	} {
+		meta[k] = true
+	}
+
+	for k, v := range e {
+		if meta[k] {
+			continue
+		}
+
+		name := strings.TrimPrefix(k, "fields.")
+		if !a.apply(e, k, name, name, v) {
+			if g, ok := v.(map[string]interface{}); ok {
+				a.anonymizeFields(g, name+".")
+			}
+		}
+	}
+}
+
+// apply applies the rule for the field of group under k, whose name
+// is name and whose path is path, if there is one, and reports whether
+// there was.
+func (a *anonymizer) apply(group map[string]interface{}, k, name, path string, v interface{}) bool {
+	act, ok := a.actions[path]
+	if !ok {
+		act, ok = a.actions[name]
+	}
+
+	switch act {
+	case drop:
+		delete(group, k)
+	case hash:
+		group[k] = a.hash(fmt.Sprint(v))
+	case generalizeIP:
+		s, _ := v.(string)
+		if ip, ok := generalize(s); ok {
+			group[k] = ip
+		} else {
+			delete(group, k)
+		}
+	}
+
+	return ok
+}
+
+func (a *anonymizer) hash(s string) string {
+	h := hmac.New(sha256.New, a.key)
+	h.Write([]byte(s))
+
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// generalize returns the network of the IP address s,
+// as a CIDR such as "192.0.2.0/24".
+func generalize(s string) (string, bool) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return "", false
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		n := &net.IPNet{IP: ip4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}
+		return n.String(), true
+	}
+
+	n := &net.IPNet{IP: ip.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}
+
+	return n.String(), true
+}
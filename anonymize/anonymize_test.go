@@ -0,0 +1,209 @@
+package anonymize
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/safe-waters/slog"
+)
+
+func TestCopy(t *testing.T) {
+	t.Parallel()
+
+	var src bytes.Buffer
+	l := slog.NewLogger(slog.WithOutput(&src))
+
+	l.Infof(slog.Fields{
+		"email":  "a@example.com",
+		"user":   "alice",
+		"ip":     "192.0.2.123",
+		"ip6":    "2001:db8:1234:5678::1",
+		"region": "eu",
+	}, "login")
+	l.Infof(slog.Fields{"user": "alice", "ip": "not an ip"}, "logout")
+	src.WriteString("\n")
+
+	var dst bytes.Buffer
+	err := Copy(&dst, &src, Rules{
+		Drop:    []string{"email"},
+		Hash:    []string{"user"},
+		HashKey: []byte("secret"),
+		IPs:     []string{"ip", "ip6"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(dst.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected '%d' line(s), got '%d'", 2, len(lines))
+	}
+
+	var events []map[string]interface{}
+	for _, line := range lines {
+		var e struct {
+			Metadata map[string]interface{} `json:"_metadata"`
+			Fields   map[string]interface{} `json:"fields"`
+			Message  string                 `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Metadata["level"] != "info" {
+			t.Fatalf("expected metadata to be kept, got '%v'", e.Metadata)
+		}
+
+		events = append(events, e.Fields)
+	}
+
+	exp := map[string]interface{}{
+		"ip":     "192.0.2.0/24",
+		"ip6":    "2001:db8:1234::/48",
+		"region": "eu",
+	}
+
+	for k, v := range exp {
+		if events[0][k] != v {
+			t.Fatalf("expected field '%s' to be '%v', got '%v'", k, v, events[0][k])
+		}
+	}
+
+	if _, ok := events[0]["email"]; ok {
+		t.Fatal("expected 'email' to be dropped, but it was not")
+	}
+
+	if _, ok := events[1]["ip"]; ok {
+		t.Fatal("expected an invalid IP to be dropped, but it was not")
+	}
+
+	user, _ := events[0]["user"].(string)
+	if user == "alice" || len(user) != 32 || user != events[1]["user"] {
+		t.Fatalf("expected 'user' to be hashed consistently, got '%v' and '%v'", user, events[1]["user"])
+	}
+}
+
+func TestCopyInvalid(t *testing.T) {
+	t.Parallel()
+
+	err := Copy(&bytes.Buffer{}, strings.NewReader("{}\nnot json\n"), Rules{})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected an error for line 2, got '%v'", err)
+	}
+}
+
+func TestCopyLayouts(t *testing.T) {
+	t.Parallel()
+
+	rules := Rules{
+		Drop:    []string{"email", "card.number"},
+		Hash:    []string{"user"},
+		HashKey: []byte("secret"),
+		IPs:     []string{"ip"},
+	}
+
+	fields := slog.Fields{
+		"email":   "a@example.com",
+		"user":    "alice",
+		"level":   "admin",
+		"region":  "eu",
+		"request": slog.Fields{"ip": "192.0.2.123", "path": "/"},
+		"card":    slog.Fields{"number": "4111", "brand": "visa"},
+	}
+
+	tests := []struct {
+		name  string
+		opts  []slog.Option
+		keys  slog.Keys
+		flat  bool
+		group func(e map[string]interface{}) map[string]interface{}
+		level string
+	}{
+		{
+			name: "nested",
+			group: func(e map[string]interface{}) map[string]interface{} {
+				f, _ := e["fields"].(map[string]interface{})
+				return f
+			},
+			level: "level",
+		},
+		{
+			name: "renamed keys",
+			opts: []slog.Option{slog.WithKeys(slog.Keys{Fields: "attrs", Metadata: "meta"})},
+			keys: slog.Keys{Fields: "attrs", Metadata: "meta"},
+			group: func(e map[string]interface{}) map[string]interface{} {
+				f, _ := e["attrs"].(map[string]interface{})
+				return f
+			},
+			level: "level",
+		},
+		{
+			name: "flat",
+			opts: []slog.Option{slog.WithFlat()},
+			flat: true,
+			group: func(e map[string]interface{}) map[string]interface{} {
+				return e
+			},
+			// The field collides with the level of the metadata.
+			level: "fields.level",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var src bytes.Buffer
+			l := slog.NewLogger(append(test.opts, slog.WithOutput(&src))...)
+			l.Infof(fields, "login")
+
+			r := rules
+			r.Keys, r.Flat = test.keys, test.flat
+
+			var dst bytes.Buffer
+			if err := Copy(&dst, &src, r); err != nil {
+				t.Fatal(err)
+			}
+
+			var e map[string]interface{}
+			if err := json.Unmarshal(dst.Bytes(), &e); err != nil {
+				t.Fatal(err)
+			}
+
+			f := test.group(e)
+			if f == nil {
+				t.Fatalf("expected fields, got '%s'", dst.String())
+			}
+
+			if _, ok := f["email"]; ok {
+				t.Fatalf("expected 'email' to be dropped, got '%s'", dst.String())
+			}
+
+			if user, _ := f["user"].(string); user == "alice" || len(user) != 32 {
+				t.Fatalf("expected 'user' to be hashed, got '%v'", f["user"])
+			}
+
+			if f["region"] != "eu" || f[test.level] != "admin" {
+				t.Fatalf("expected other fields to be kept, got '%s'", dst.String())
+			}
+
+			req, _ := f["request"].(map[string]interface{})
+			if req["ip"] != "192.0.2.0/24" || req["path"] != "/" {
+				t.Fatalf("expected 'request.ip' to be generalized, got '%v'", req)
+			}
+
+			card, _ := f["card"].(map[string]interface{})
+			if _, ok := card["number"]; ok || card["brand"] != "visa" {
+				t.Fatalf("expected only 'card.number' to be dropped, got '%v'", card)
+			}
+
+			if test.flat && (e["level"] != "info" || e["message"] != "login") {
+				t.Fatalf("expected metadata and message to be kept, got '%s'", dst.String())
+			}
+		})
+	}
+}
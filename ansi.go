@@ -0,0 +1,32 @@
+package slog
+
+import "regexp"
+
+// ansiRe matches ANSI escape sequences: control sequences, such as
+// colors and cursor movements, operating system commands, such as
+// window titles and hyperlinks, and other two-byte escapes.
+var ansiRe = regexp.MustCompile(`\x1b(\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\)|[0-~])`)
+
+// WithStripANSI makes the Logger remove ANSI escape sequences from
+// messages and field values, so that colored text, or escape sequences
+// injected through user-provided values, do not end up in archived
+// logs, where they are noise at best and can rewrite the terminal
+// of whoever views the logs at worst.
+func WithStripANSI() Option {
+	return func(l *Logger) {
+		l.stripANSI = true
+	}
+}
+
+// stripANSI removes ANSI escape sequences from the values
+// of f, recursively.
+func stripANSI(f Fields) {
+	for k, v := range f {
+		switch v := v.(type) {
+		case string:
+			f[k] = ansiRe.ReplaceAllString(v, "")
+		case Fields:
+			stripANSI(v)
+		}
+	}
+}
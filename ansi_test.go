@@ -0,0 +1,69 @@
+package slog
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStripANSI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		exp  string
+	}{
+		{name: "plain", in: "hello", exp: "hello"},
+		{name: "color", in: "\x1b[31mred\x1b[0m", exp: "red"},
+		{name: "cursor", in: "a\x1b[2K\x1b[1Ab", exp: "ab"},
+		{name: "title", in: "\x1b]0;pwned\x07text", exp: "text"},
+		{name: "hyperlink", in: "\x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\", exp: "link"},
+		{name: "two-byte", in: "\x1bcreset", exp: "reset"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			l := NewLogger(WithOutput(mw), WithStripANSI()).With(Fields{"permanent": test.in})
+			l.Infof(Fields{"value": test.in, "group": Fields{"value": test.in}}, test.in)
+
+			var e struct {
+				Fields struct {
+					Value     string            `json:"value"`
+					Permanent string            `json:"permanent"`
+					Group     map[string]string `json:"group"`
+				} `json:"fields"`
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(mw.byt, &e); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, got := range []string{e.Message, e.Fields.Value, e.Fields.Permanent, e.Fields.Group["value"]} {
+				if got != test.exp {
+					t.Fatalf("expected '%q', got '%q'", test.exp, got)
+				}
+			}
+		})
+	}
+}
+
+func TestStripANSIOff(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	NewLogger(WithOutput(mw)).Info("\x1b[31mred\x1b[0m")
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Message != "\x1b[31mred\x1b[0m" {
+		t.Fatalf("expected escape sequences to be kept, got '%q'", e.Message)
+	}
+}
@@ -0,0 +1,116 @@
+package slog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// BaggageHeader is the HTTP header that carries baggage between services.
+// Its lowercase form, "slog-baggage", is suitable as a gRPC metadata key.
+const BaggageHeader = "Slog-Baggage"
+
+type baggageKey struct{}
+
+func init() {
+	RegisterContextExtractor(BaggageFromContext)
+}
+
+// WithBaggage returns a copy of ctx that carries f, for example the
+// Fields returned by ExtractBaggage, in addition to the baggage ctx
+// already carries. Fields in f replace those of the same name.
+//
+// Baggage is added to every event logged with the context, by methods
+// such as InfoCtx and LogCtx, and to the Logger returned by FromContext,
+// so that fields such as "request_id" and "tenant" flow across services:
+//
+//	ctx := slog.WithBaggage(r.Context(), slog.ExtractBaggage(r.Header))
+//	slog.InfoCtx(ctx, "handling request")
+func WithBaggage(ctx context.Context, f Fields) context.Context {
+	if len(f) == 0 {
+		return ctx
+	}
+
+	old := BaggageFromContext(ctx)
+
+	b := make(Fields, len(old)+len(f))
+	for k, v := range old {
+		b[k] = v
+	}
+	for k, v := range f {
+		b[k] = v
+	}
+
+	return context.WithValue(ctx, baggageKey{}, b)
+}
+
+// BaggageFromContext returns the baggage carried by ctx, or nil if
+// it carries none, so that it can be passed on with InjectBaggage.
+// It is registered as a ContextExtractor.
+func BaggageFromContext(ctx context.Context) Fields {
+	f, _ := ctx.Value(baggageKey{}).(Fields)
+	return f
+}
+
+// EncodeBaggage encodes the fields in f named by keys, such as
+// "request_id" or "tenant", so that they can travel to another service.
+// Keys missing from f are skipped. If no keys are given,
+// all of f is encoded.
+func EncodeBaggage(f Fields, keys ...string) string {
+	vals := url.Values{}
+
+	if len(keys) == 0 {
+		for k := range f {
+			keys = append(keys, k)
+		}
+	}
+
+	for _, k := range keys {
+		v, ok := f[k]
+		if !ok {
+			continue
+		}
+		vals.Set(k, formatValue(v))
+	}
+
+	return vals.Encode()
+}
+
+// DecodeBaggage decodes baggage produced by EncodeBaggage into Fields.
+func DecodeBaggage(s string) (Fields, error) {
+	vals, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, err
+	}
+
+	f := make(Fields, len(vals))
+	for k := range vals {
+		f[k] = vals.Get(k)
+	}
+
+	return f, nil
+}
+
+// InjectBaggage sets the BaggageHeader on h from the fields in f
+// named by keys, as described by EncodeBaggage.
+func InjectBaggage(h http.Header, f Fields, keys ...string) {
+	if s := EncodeBaggage(f, keys...); s != "" {
+		h.Set(BaggageHeader, s)
+	}
+}
+
+// ExtractBaggage returns the Fields carried by the BaggageHeader on h.
+// It returns nil if the header is missing or malformed.
+func ExtractBaggage(h http.Header) Fields {
+	s := h.Get(BaggageHeader)
+	if s == "" {
+		return nil
+	}
+
+	f, err := DecodeBaggage(s)
+	if err != nil {
+		return nil
+	}
+
+	return f
+}
@@ -0,0 +1,103 @@
+package slog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestBaggage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		f    Fields
+		keys []string
+		expF Fields
+	}{
+		{
+			name: "selected keys",
+			f:    Fields{"request_id": "abc", "tenant": "acme", "secret": "x"},
+			keys: []string{"request_id", "tenant", "missing"},
+			expF: Fields{"request_id": "abc", "tenant": "acme"},
+		},
+		{
+			name: "all keys",
+			f:    Fields{"request_id": "a&b=c", "attempt": 2, "empty": nil},
+			expF: Fields{"request_id": "a&b=c", "attempt": "2", "empty": "nil"},
+		},
+		{
+			name: "no keys",
+			f:    Fields{"secret": "x"},
+			keys: []string{"request_id"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := http.Header{}
+			InjectBaggage(h, test.f, test.keys...)
+
+			f := ExtractBaggage(h)
+			if len(test.expF) != len(f) {
+				t.Fatalf(
+					"expected '%d' field(s), got '%d'",
+					len(test.expF),
+					len(f),
+				)
+			}
+
+			for k := range test.expF {
+				if test.expF[k] != f[k] {
+					t.Fatalf("expected field '%s', got '%s'", test.expF[k], f[k])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeBaggageMalformed(t *testing.T) {
+	t.Parallel()
+
+	if _, err := DecodeBaggage("%zz"); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
+
+func TestBaggageContext(t *testing.T) {
+	t.Parallel()
+
+	h := http.Header{}
+	InjectBaggage(h, Fields{"request_id": "abc", "tenant": "acme"})
+
+	ctx := WithBaggage(context.Background(), ExtractBaggage(h))
+	ctx = WithBaggage(ctx, Fields{"tenant": "other"})
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw))
+
+	for _, fn := range []func(){
+		func() { l.InfoCtx(ctx, "hello") },
+		func() { FromContext(NewContext(ctx, l)).Info("hello") },
+	} {
+		fn()
+
+		var e event
+		if err := json.Unmarshal(mw.byt, &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Fields["request_id"] != "abc" || e.Fields["tenant"] != "other" {
+			t.Fatalf("expected the baggage in the fields, got '%v'", e.Fields)
+		}
+	}
+
+	if f := BaggageFromContext(context.Background()); f != nil {
+		t.Fatalf("expected no baggage, got '%v'", f)
+	}
+}
@@ -0,0 +1,184 @@
+package slog
+
+import (
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultBudgetSampling is the number of events over budget of which
+// a BudgetSink without a spool keeps one.
+const defaultBudgetSampling = 100
+
+// BudgetPeriod is the period over which a Budget is counted.
+// Periods start at midnight UTC.
+type BudgetPeriod int
+
+const (
+	// Daily budgets start over every day.
+	Daily BudgetPeriod = iota
+	// Monthly budgets start over on the first day of every month.
+	Monthly
+)
+
+// String returns "daily" or "monthly".
+func (p BudgetPeriod) String() string {
+	if p == Monthly {
+		return "monthly"
+	}
+
+	return "daily"
+}
+
+// start returns the start of the period that t is in.
+func (p BudgetPeriod) start(t time.Time) time.Time {
+	t = t.UTC()
+	if p == Monthly {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Budget limits the number of bytes written to a sink per period.
+type Budget struct {
+	// Bytes is the number of bytes that may be written per Period.
+	Bytes  int64
+	Period BudgetPeriod
+
+	// Spool receives the events over budget, for example a local file,
+	// so that they are kept but not shipped. If Spool is nil, one in
+	// every Sampling events over budget is still written to the sink
+	// and the others are dropped.
+	Spool io.Writer
+
+	// Sampling defaults to 100.
+	Sampling int
+}
+
+// BudgetSink is a sink that guards against surprise bills from
+// logging services that charge by volume. Once the bytes written to
+// it in a period exceed its Budget, it downgrades to sampling or
+// to spooling events locally until the next period, and writes
+// a warning to the sink with the "budget_bytes", "used_bytes",
+// "period", and "action".
+//
+//	s := slog.NewBudgetSink(conn, slog.Budget{Bytes: 5 << 30, Period: slog.Daily})
+//	l := slog.NewLogger(slog.WithOutput(s))
+//
+// Writes to the sink are serialized, so it need not be safe
+// for concurrent use.
+type BudgetSink struct {
+	mu  sync.Mutex
+	w   io.Writer
+	b   Budget
+	now func() time.Time
+
+	start   time.Time
+	used    int64
+	over    int
+	spooled int
+	dropped int
+}
+
+// BudgetStats describes the consumption of a BudgetSink's Budget
+// in the current period.
+type BudgetStats struct {
+	// Start is when the period started.
+	Start time.Time `json:"start"`
+	// Period is "daily" or "monthly".
+	Period string `json:"period"`
+	// Budget is the number of bytes that may be written per period.
+	Budget int64 `json:"budget_bytes"`
+	// Used is the number of bytes written to the sink.
+	Used int64 `json:"used_bytes"`
+	// Spooled and Dropped are the number of events over budget that
+	// were written to the spool or dropped.
+	Spooled int `json:"spooled"`
+	Dropped int `json:"dropped"`
+}
+
+// NewBudgetSink returns a BudgetSink that writes to w within b.
+func NewBudgetSink(w io.Writer, b Budget) *BudgetSink {
+	if b.Sampling <= 0 {
+		b.Sampling = defaultBudgetSampling
+	}
+
+	return &BudgetSink{w: w, b: b, now: time.Now}
+}
+
+// Write writes p to the sink if it is within budget, and otherwise
+// to the spool or, if it is sampled, to the sink.
+func (s *BudgetSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if start := s.b.Period.start(now); !start.Equal(s.start) {
+		s.start = start
+		s.used, s.over, s.spooled, s.dropped = 0, 0, 0, 0
+	}
+
+	if s.used+int64(len(p)) <= s.b.Bytes {
+		s.used += int64(len(p))
+		return s.w.Write(p)
+	}
+
+	s.over++
+	if s.over == 1 {
+		s.advise(now)
+	}
+
+	if s.b.Spool != nil {
+		s.spooled++
+		return s.b.Spool.Write(p)
+	}
+
+	if (s.over-1)%s.b.Sampling != 0 {
+		s.dropped++
+		return len(p), nil
+	}
+
+	s.used += int64(len(p))
+	return s.w.Write(p)
+}
+
+// advise writes a warning that the budget was exceeded to the sink.
+func (s *BudgetSink) advise(now time.Time) {
+	action := "sampling 1 in " + strconv.Itoa(s.b.Sampling)
+	if s.b.Spool != nil {
+		action = "spooling"
+	}
+
+	e := &event{
+		Metadata: Fields{
+			"level": string(WarnLevel),
+			"time":  now.Format(time.RFC3339Nano),
+		},
+		Fields: Fields{
+			"budget_bytes": strconv.FormatInt(s.b.Bytes, 10),
+			"used_bytes":   strconv.FormatInt(s.used, 10),
+			"period":       s.b.Period.String(),
+			"action":       action,
+		},
+		Message: "log budget exceeded",
+	}
+
+	s.w.Write(append(marshalEvent(e), '\n'))
+}
+
+// Stats returns the consumption of the budget in the current period.
+func (s *BudgetSink) Stats() BudgetStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return BudgetStats{
+		Start:   s.start,
+		Period:  s.b.Period.String(),
+		Budget:  s.b.Bytes,
+		Used:    s.used,
+		Spooled: s.spooled,
+		Dropped: s.dropped,
+	}
+}
@@ -0,0 +1,105 @@
+package slog
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBudgetSink(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2021, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		b       Budget
+		exp     []string
+		spooled []string
+		stats   BudgetStats
+	}{
+		{
+			name:  "sampling",
+			b:     Budget{Bytes: 4, Period: Daily, Sampling: 2},
+			exp:   []string{"a\n", "b\n", "log budget exceeded", "c\n", "e\n"},
+			stats: BudgetStats{Period: "daily", Budget: 4, Used: 8, Dropped: 2},
+		},
+		{
+			name:    "spooling",
+			b:       Budget{Bytes: 4, Period: Monthly, Spool: &linesWriter{}},
+			exp:     []string{"a\n", "b\n", "log budget exceeded"},
+			spooled: []string{"c", "d", "e", "f"},
+			stats:   BudgetStats{Period: "monthly", Budget: 4, Used: 4, Spooled: 4},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := &linesWriter{}
+			s := NewBudgetSink(w, test.b)
+			s.now = func() time.Time { return day }
+
+			for _, p := range []string{"a\n", "b\n", "c\n", "d\n", "e\n", "f\n"} {
+				if _, err := s.Write([]byte(p)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if len(w.lines) != len(test.exp) {
+				t.Fatalf("expected '%d' line(s), got '%d': %q", len(test.exp), len(w.lines), w.lines)
+			}
+			for i, exp := range test.exp {
+				if !strings.Contains(w.lines[i], strings.TrimSpace(exp)) {
+					t.Fatalf("expected '%s' in '%s'", exp, w.lines[i])
+				}
+			}
+
+			if test.spooled != nil {
+				if got := test.b.Spool.(*linesWriter).lines; strings.Join(got, ",") != strings.Join(test.spooled, ",") {
+					t.Fatalf("expected spooled '%v', got '%v'", test.spooled, got)
+				}
+			}
+
+			stats := s.Stats()
+			test.stats.Start = test.b.Period.start(day)
+			if stats != test.stats {
+				t.Fatalf("expected stats '%+v', got '%+v'", test.stats, stats)
+			}
+		})
+	}
+}
+
+func TestBudgetSinkPeriod(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2021, 3, 31, 23, 0, 0, 0, time.UTC)
+
+	w := &linesWriter{}
+	s := NewBudgetSink(w, Budget{Bytes: 2, Period: Daily, Sampling: 1000})
+	s.now = func() time.Time { return now }
+
+	s.Write([]byte("a\n"))
+	s.Write([]byte("b\n"))
+	s.Write([]byte("c\n"))
+
+	now = now.Add(2 * time.Hour)
+	s.Write([]byte("d\n"))
+
+	exp := []string{"a", "log budget exceeded", "b", "d"}
+	if len(w.lines) != len(exp) {
+		t.Fatalf("expected '%d' line(s), got '%d': %q", len(exp), len(w.lines), w.lines)
+	}
+	for i := range exp {
+		if !strings.Contains(w.lines[i], exp[i]) {
+			t.Fatalf("expected '%s' in '%s'", exp[i], w.lines[i])
+		}
+	}
+
+	if stats := s.Stats(); stats.Used != 2 || stats.Dropped != 0 || !stats.Start.Equal(Daily.start(now)) {
+		t.Fatalf("expected the budget to start over, got '%+v'", stats)
+	}
+}
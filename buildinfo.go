@@ -0,0 +1,48 @@
+package slog
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	buildInfoOnce sync.Once
+	buildInfo     Fields
+)
+
+// WithBuildInfo makes the Logger log the build of the binary in the
+// metadata of every event, as read by debug.ReadBuildInfo, so that
+// events can be matched with deployed versions: the "version" of the
+// main module and, when the binary was built with Go 1.18 or later from
+// a VCS checkout, the "vcs_revision" and the "vcs_time" of the commit
+// it was built from. Go does not record the time of the build itself.
+// Values that are unknown are omitted.
+func WithBuildInfo() Option {
+	return func(l *Logger) {
+		buildInfoOnce.Do(func() {
+			buildInfo = readBuildInfo()
+		})
+
+		for k, v := range buildInfo {
+			l.setRunMetadata(k, v)
+		}
+	}
+}
+
+func readBuildInfo() Fields {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok || bi == nil {
+		return nil
+	}
+
+	f := Fields{}
+	if v := bi.Main.Version; v != "" {
+		f["version"] = v
+	}
+
+	for k, v := range vcsInfo(bi) {
+		f[k] = v
+	}
+
+	return f
+}
@@ -0,0 +1,12 @@
+//go:build !go1.18
+// +build !go1.18
+
+package slog
+
+import "runtime/debug"
+
+// vcsInfo returns nothing, because build information
+// only records the VCS from Go 1.18.
+func vcsInfo(bi *debug.BuildInfo) Fields {
+	return nil
+}
@@ -0,0 +1,33 @@
+package slog
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWithBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	NewLogger(WithOutput(mw), WithBuildInfo()).Info("hello")
+
+	var e struct {
+		Metadata map[string]string `json:"_metadata"`
+	}
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := readBuildInfo()
+	for k, v := range exp {
+		if e.Metadata[k] != v {
+			t.Fatalf("expected '%s' to be '%v', got '%s'", k, v, e.Metadata[k])
+		}
+	}
+
+	for _, k := range []string{"version", "vcs_revision", "vcs_time"} {
+		if _, ok := exp[k]; !ok && e.Metadata[k] != "" {
+			t.Fatalf("expected no '%s', got '%s'", k, e.Metadata[k])
+		}
+	}
+}
@@ -0,0 +1,21 @@
+//go:build go1.18
+// +build go1.18
+
+package slog
+
+import "runtime/debug"
+
+// vcsInfo returns the VCS revision and commit time that bi records.
+func vcsInfo(bi *debug.BuildInfo) Fields {
+	f := Fields{}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			f["vcs_revision"] = s.Value
+		case "vcs.time":
+			f["vcs_time"] = s.Value
+		}
+	}
+
+	return f
+}
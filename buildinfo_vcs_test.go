@@ -0,0 +1,36 @@
+//go:build go1.18
+// +build go1.18
+
+package slog
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestVCSInfo(t *testing.T) {
+	t.Parallel()
+
+	bi := &debug.BuildInfo{
+		Settings: []debug.BuildSetting{
+			{Key: "-compiler", Value: "gc"},
+			{Key: "vcs", Value: "git"},
+			{Key: "vcs.revision", Value: "0123abc"},
+			{Key: "vcs.time", Value: "2021-01-01T00:00:00Z"},
+			{Key: "vcs.modified", Value: "false"},
+		},
+	}
+
+	f := vcsInfo(bi)
+	exp := Fields{"vcs_revision": "0123abc", "vcs_time": "2021-01-01T00:00:00Z"}
+
+	if len(f) != len(exp) {
+		t.Fatalf("expected '%v', got '%v'", exp, f)
+	}
+
+	for k, v := range exp {
+		if f[k] != v {
+			t.Fatalf("expected '%s' to be '%v', got '%v'", k, v, f[k])
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package slog
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+)
+
+const (
+	// hllPrecision is the number of bits of a hash that select
+	// a HyperLogLog register, giving 1024 registers per field key
+	// and a standard error of about 3%.
+	hllPrecision = 10
+	hllRegisters = 1 << hllPrecision
+
+	// maxCardinalityKeys bounds the number of field keys
+	// tracked by the cardinality guard.
+	maxCardinalityKeys = 1000
+)
+
+// SetCardinalityLimit protects log indexes, such as those of Loki or
+// Elasticsearch, from cardinality explosions. The Logger estimates the
+// number of distinct values of every field key over each window and,
+// once a key has more than limit values in a window, drops that field
+// from events for the rest of the window.
+//
+// The first time a key is dropped in a window, the Logger logs a warning
+// with the "field", its "estimated_cardinality", and the "limit".
+// Estimates are approximate, with a standard error of about 3%.
+//
+// If limit or window is not positive, the guard is turned off,
+// which is the default.
+func (l *Logger) SetCardinalityLimit(limit int, window time.Duration) {
+	l.mu.Lock()
+	old := l.cfg.cardinality.String()
+
+	if limit <= 0 || window <= 0 {
+		l.cfg.cardinality = nil
+	} else {
+		l.cfg.cardinality = &cardinalityGuard{
+			limit:  limit,
+			window: window,
+			keys:   make(map[string]*hll),
+		}
+	}
+
+	new := l.cfg.cardinality.String()
+	l.mu.Unlock()
+
+	l.configChanged("cardinality_limit", old, new)
+}
+
+// String describes the limit and window of g, or returns "off"
+// if g is nil.
+func (g *cardinalityGuard) String() string {
+	if g == nil {
+		return "off"
+	}
+
+	return fmt.Sprintf("%d per %s", g.limit, g.window)
+}
+
+type cardinalityGuard struct {
+	limit  int
+	window time.Duration
+
+	mu    sync.Mutex
+	start time.Time
+	keys  map[string]*hll
+}
+
+// guard drops the fields of e whose keys are over the limit,
+// and returns advisories for the keys that went over it.
+func (g *cardinalityGuard) guard(e *Event) []*Event {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if e.Time.Sub(g.start) >= g.window {
+		g.start = e.Time
+		g.keys = make(map[string]*hll)
+	}
+
+	var advisories []*Event
+
+	for k, v := range e.Fields {
+		h, ok := g.keys[k]
+		if !ok {
+			if len(g.keys) >= maxCardinalityKeys {
+				continue
+			}
+			h = &hll{}
+			g.keys[k] = h
+		}
+
+		s, ok := v.(string)
+		if !ok {
+			s = fmt.Sprint(v)
+		}
+		h.add(s)
+
+		if !h.dropped {
+			n := h.estimate()
+			if n <= float64(g.limit) {
+				continue
+			}
+
+			h.dropped = true
+			advisories = append(advisories, &Event{
+				Level: WarnLevel,
+				File:  e.File,
+				Time:  e.Time,
+				Fields: Fields{
+					"field":                 k,
+					"estimated_cardinality": formatValue(int(math.Round(n))),
+					"limit":                 formatValue(g.limit),
+				},
+				Message: "high-cardinality field dropped",
+			})
+		}
+
+		delete(e.Fields, k)
+	}
+
+	return advisories
+}
+
+// hll is a HyperLogLog sketch that estimates
+// the number of distinct strings added to it.
+type hll struct {
+	registers [hllRegisters]uint8
+	dropped   bool
+}
+
+func (h *hll) add(s string) {
+	f := fnv.New64a()
+	f.Write([]byte(s))
+	x := mix64(f.Sum64())
+
+	i := x >> (64 - hllPrecision)
+	rank := uint8(bits.LeadingZeros64(x<<hllPrecision|1<<(hllPrecision-1)) + 1)
+
+	if rank > h.registers[i] {
+		h.registers[i] = rank
+	}
+}
+
+func (h *hll) estimate() float64 {
+	var (
+		sum   float64
+		zeros int
+	)
+
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	const m = float64(hllRegisters)
+	e := 0.7213 / (1 + 1.079/m) * m * m / sum
+
+	// Use linear counting for small cardinalities,
+	// where HyperLogLog is biased.
+	if e <= 2.5*m && zeros > 0 {
+		e = m * math.Log(m/float64(zeros))
+	}
+
+	return e
+}
+
+// mix64 spreads the bits of x, because the low bits
+// of FNV hashes of similar strings are correlated.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
@@ -0,0 +1,82 @@
+package slog
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestHLL(t *testing.T) {
+	t.Parallel()
+
+	tests := []int{0, 1, 10, 100, 1000, 10000, 100000}
+
+	for _, n := range tests {
+		h := &hll{}
+		for i := 0; i < n; i++ {
+			h.add("value-" + strconv.Itoa(i))
+			h.add("value-" + strconv.Itoa(i))
+		}
+
+		e := h.estimate()
+		if math.Abs(e-float64(n)) > 0.1*float64(n)+1 {
+			t.Fatalf("expected an estimate near '%d', got '%f'", n, e)
+		}
+	}
+}
+
+func TestSetCardinalityLimit(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w), WithFields(Fields{"service": "api"}))
+	l.SetCardinalityLimit(10, time.Hour)
+
+	for i := 0; i < 100; i++ {
+		l.Infof(Fields{"user": i, "region": i % 3}, "request")
+	}
+
+	var (
+		advisories []event
+		last       event
+	)
+
+	for _, line := range w.lines {
+		var e event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Metadata["level"] == string(WarnLevel) {
+			advisories = append(advisories, e)
+			continue
+		}
+		last = e
+	}
+
+	if len(advisories) != 1 || advisories[0].Fields["field"] != "user" {
+		t.Fatalf("expected one advisory for '%s', got '%v'", "user", advisories)
+	}
+
+	if _, ok := last.Fields["user"]; ok {
+		t.Fatal("expected the 'user' field to be dropped, but it was not")
+	}
+
+	if last.Fields["region"] != "0" || last.Fields["service"] != "api" {
+		t.Fatalf("expected the other fields to be kept, got '%v'", last.Fields)
+	}
+
+	l.SetCardinalityLimit(0, time.Hour)
+	l.Infof(Fields{"user": 1000}, "request")
+
+	var e event
+	if err := json.Unmarshal([]byte(w.lines[len(w.lines)-1]), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Fields["user"] != "1000" {
+		t.Fatalf("expected field '%s' once the guard is off, got '%v'", "1000", e.Fields["user"])
+	}
+}
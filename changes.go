@@ -0,0 +1,125 @@
+package slog
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SetConfigEvents makes the Logger log an info event whenever its
+// configuration changes at runtime, for example when its level is
+// changed with SetLevel or a Hook is added, giving an audit trail of
+// changes to observability. The event has the "setting" that changed
+// and its "old" and "new" values as fields. It is logged regardless
+// of the Logger's level.
+//
+// Settings shared by Loggers are audited too: changes to the Logger's
+// AtomicLevel, including those made through its ServeHTTP method, and
+// to its Targets, as well as named levels set with SetNamedLevel.
+// While config events are on, the package keeps a reference to the
+// Logger to tell it about those changes.
+//
+// Config events are off by default.
+func (l *Logger) SetConfigEvents(enabled bool) {
+	l.mu.Lock()
+	old := l.cfg.configEvents
+	l.cfg.configEvents = enabled
+	l.mu.Unlock()
+
+	changeLoggersMu.Lock()
+	if enabled {
+		changeLoggers[l] = true
+	} else {
+		delete(changeLoggers, l)
+	}
+	changeLoggersMu.Unlock()
+
+	l.configChanged("config_events", old, enabled)
+}
+
+var (
+	changeLoggersMu sync.RWMutex
+	// changeLoggers holds the Loggers with config events on,
+	// which notifyChange tells about changes to shared settings.
+	changeLoggers = map[*Logger]bool{}
+)
+
+// notifyChange logs that a setting shared by Loggers changed from old
+// to new with every Logger that has config events on and uses src,
+// which is the AtomicLevel or Targets that changed, or nil for settings
+// of every Logger, such as named levels. Like configChanged, it must
+// be called directly by the exported method that changed the setting.
+func notifyChange(src interface{}, setting string, old, new interface{}) {
+	changeLoggersMu.RLock()
+	ls := make([]*Logger, 0, len(changeLoggers))
+	for l := range changeLoggers {
+		ls = append(ls, l)
+	}
+	changeLoggersMu.RUnlock()
+
+	for _, l := range ls {
+		if l.uses(src) {
+			l.changed(1, setting, old, new)
+		}
+	}
+}
+
+// uses reports whether src is the Logger's AtomicLevel or Targets,
+// or nil.
+func (l *Logger) uses(src interface{}) bool {
+	switch src := src.(type) {
+	case nil:
+		return true
+	case *AtomicLevel:
+		return l.getConfig().atomicLevel == src
+	case *Targets:
+		return l.targets == src
+	}
+
+	return false
+}
+
+// configChanged logs that setting changed from old to new, if config
+// events are on. It must be called directly by the exported method
+// that changed the setting, so that the caller's file is logged.
+func (l *Logger) configChanged(setting string, old, new interface{}) {
+	l.changed(1, setting, old, new)
+}
+
+// changed implements configChanged, taking the file of the caller
+// skip frames above the exported method that changed the setting.
+func (l *Logger) changed(skip int, setting string, old, new interface{}) {
+	if !l.getConfig().configEvents {
+		return
+	}
+
+	o, n := formatValue(old), formatValue(new)
+	if o == n {
+		return
+	}
+
+	var file string
+	if !l.noCaller {
+		file, _ = l.fileInfo(skip)
+	}
+
+	l.write(&Event{
+		Level: InfoLevel,
+		File:  file,
+		Time:  l.now(),
+		Fields: Fields{
+			"setting": setting,
+			"old":     o,
+			"new":     n,
+		},
+		Message: "logger configuration changed",
+	})
+}
+
+// describeType returns the type of v, or "none" if v is nil.
+func describeType(v interface{}) string {
+	if v == nil {
+		return "none"
+	}
+
+	return fmt.Sprintf("%T", v)
+}
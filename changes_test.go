@@ -0,0 +1,188 @@
+package slog
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncLinesWriter is a linesWriter that is safe for concurrent use,
+// because named levels set by tests running in parallel are reported
+// to every Logger with config events on.
+type syncLinesWriter struct {
+	mu sync.Mutex
+	w  linesWriter
+}
+
+func (w *syncLinesWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.w.Write(p)
+}
+
+func (w *syncLinesWriter) lines() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]string(nil), w.w.lines...)
+}
+
+func TestConfigEvents(t *testing.T) {
+	t.Parallel()
+
+	w := &syncLinesWriter{}
+	l := NewLogger(WithOutput(w))
+
+	l.SetLevel(DebugLevel)
+	if n := len(w.lines()); n != 0 {
+		t.Fatalf("expected no config events while they are off, got '%d'", n)
+	}
+
+	l.SetConfigEvents(true)
+	l.SetLevel(WarnLevel)
+	l.SetLevel(WarnLevel)
+	l.SetAtomicLevel(NewAtomicLevel(ErrorLevel))
+	l.AddHook(HookFunc(func(*Event) {}))
+	l.SetSampling(100)
+	l.SetDevelopment(true)
+	l.SetCardinalityLimit(10, time.Minute)
+	l.SetEventID(&SequenceGenerator{})
+	l.SetFingerprint(true, "b", "a")
+	l.SetSeverity("severity", SyslogSeverity)
+	l.SetFieldDefaults(Fields{"env": "unknown"})
+	l.Aggregate("request", "", time.Minute)
+	l.SetOutput(w)
+
+	exp := []struct{ setting, old, new string }{
+		{"config_events", "false", "true"},
+		{"level", "debug", "warn"},
+		{"level", "warn", "error"},
+		{"hooks", "0", "1"},
+		{"sampling", "0", "100"},
+		{"development", "false", "true"},
+		{"cardinality_limit", "off", "10 per 1m0s"},
+		{"event_id", "none", "*slog.SequenceGenerator"},
+		{"fingerprint", "off", "[a b]"},
+		{"severity_key", "", "severity"},
+		{"field_defaults", "off", "[env]"},
+		{"aggregates", "off", "[request]"},
+	}
+
+	// Named levels set by tests running in parallel
+	// are reported too, so they are skipped.
+	var events []event
+	for _, line := range w.lines() {
+		var e event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Fields["setting"] != "named_level" {
+			events = append(events, e)
+		}
+	}
+
+	if len(events) != len(exp) {
+		t.Fatalf("expected '%d' config event(s), got '%d': %v", len(exp), len(events), w.lines())
+	}
+
+	for i, e := range events {
+		if e.Fields["setting"] != exp[i].setting ||
+			e.Fields["old"] != exp[i].old ||
+			e.Fields["new"] != exp[i].new {
+			t.Fatalf("expected change '%v', got '%v'", exp[i], e.Fields)
+		}
+
+		if !strings.HasPrefix(e.Metadata["file"].(string), "changes_test.go:") {
+			t.Fatalf("expected file '%s', got '%s'", "changes_test.go", e.Metadata["file"])
+		}
+	}
+}
+
+func TestSharedConfigEvents(t *testing.T) {
+	t.Parallel()
+
+	name := t.Name()
+
+	tests := []struct {
+		name              string
+		change            func(a *AtomicLevel, tg *Targets)
+		setting, old, new string
+	}{
+		{
+			name:    "atomic level",
+			change:  func(a *AtomicLevel, _ *Targets) { a.SetLevel(DebugLevel) },
+			setting: "level", old: "info", new: "debug",
+		},
+		{
+			name:    "named level",
+			change:  func(*AtomicLevel, *Targets) { SetNamedLevel(name, ErrorLevel) },
+			setting: "named_level", old: name + "=none", new: name + "=error",
+		},
+		{
+			name: "targets",
+			change: func(_ *AtomicLevel, tg *Targets) {
+				tg.Enable(DebugLevel, Fields{"user_id": "123"}, time.Minute)
+			},
+			setting: "targets", old: "0", new: "1",
+		},
+	}
+
+	for _, test := range tests {
+		w := &syncLinesWriter{}
+		a := NewAtomicLevel(InfoLevel)
+		tg := NewTargets()
+
+		l := NewLogger(WithOutput(w), WithTargets(tg))
+		l.SetAtomicLevel(a)
+		l.SetConfigEvents(true)
+
+		// Another Logger with config events on, which uses neither a nor tg.
+		other := &syncLinesWriter{}
+		o := NewLogger(WithOutput(other))
+		o.SetConfigEvents(true)
+
+		test.change(a, tg)
+
+		l.SetConfigEvents(false)
+		o.SetConfigEvents(false)
+
+		var found bool
+		for _, line := range w.lines() {
+			var e event
+			if err := json.Unmarshal([]byte(line), &e); err != nil {
+				t.Fatal(err)
+			}
+
+			if e.Fields["setting"] != test.setting || e.Fields["old"] != test.old {
+				continue
+			}
+			found = true
+
+			if e.Fields["new"] != test.new {
+				t.Fatalf("%s: expected new value '%s', got '%v'", test.name, test.new, e.Fields["new"])
+			}
+
+			if file, _ := e.Metadata["file"].(string); !strings.HasPrefix(file, "changes_test.go:") {
+				t.Fatalf("%s: expected file '%s', got '%s'", test.name, "changes_test.go", file)
+			}
+		}
+
+		if !found {
+			t.Fatalf("%s: expected a '%s' config event, got '%v'", test.name, test.setting, w.lines())
+		}
+
+		if test.setting != "named_level" {
+			for _, line := range other.lines() {
+				if strings.Contains(line, `"setting":"`+test.setting+`"`) {
+					t.Fatalf("%s: expected no event for a Logger that does not use the setting, got '%s'", test.name, line)
+				}
+			}
+		}
+	}
+
+	SetNamedLevel(name, "")
+}
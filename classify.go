@@ -0,0 +1,121 @@
+package slog
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ErrorClass describes a kind of error, so that error telemetry is
+// standardized across a codebase.
+//
+// When a Logger logs an error, as the message or as a field value,
+// that matches a registered ErrorClass, it adds the fields
+// "error.kind", "error.retryable", and "error.http_status"
+// (the latter only if HTTPStatus is not 0).
+type ErrorClass struct {
+	Kind       string
+	Retryable  bool
+	HTTPStatus int
+}
+
+// ErrorClassifier returns the ErrorClass of err and true,
+// or false if it does not know err.
+type ErrorClassifier func(err error) (ErrorClass, bool)
+
+var (
+	classifiersMu sync.RWMutex
+	classifiers   []ErrorClassifier
+)
+
+// RegisterErrorClassifier registers fn to classify logged errors.
+// Classifiers are consulted in the order they were registered,
+// and the first one that knows an error wins.
+func RegisterErrorClassifier(fn ErrorClassifier) {
+	classifiersMu.Lock()
+	defer classifiersMu.Unlock()
+
+	classifiers = append(classifiers, fn)
+}
+
+// RegisterErrorClass registers c as the ErrorClass of every error
+// that errors.As can assign to a variable of the same type as target,
+// for example:
+//
+//	slog.RegisterErrorClass((*os.PathError)(nil), slog.ErrorClass{Kind: "fs"})
+//
+// In TinyGo builds, an error matches if an error in its chain has
+// the same type as target, without calling As methods.
+//
+// RegisterErrorClass panics if target is nil.
+func RegisterErrorClass(target error, c ErrorClass) {
+	if target == nil {
+		panic("slog: RegisterErrorClass target must not be nil")
+	}
+
+	matches := errorMatcher(target)
+
+	RegisterErrorClassifier(func(err error) (ErrorClass, bool) {
+		return c, matches(err)
+	})
+}
+
+func classifyError(err error) (ErrorClass, bool) {
+	classifiersMu.RLock()
+	defer classifiersMu.RUnlock()
+
+	for _, fn := range classifiers {
+		if c, ok := fn(err); ok {
+			return c, true
+		}
+	}
+
+	return ErrorClass{}, false
+}
+
+// addErrorClass adds the fields of the ErrorClass of the error in msg
+// or, failing that, of the error in f with the smallest key, to combined,
+// without overwriting existing keys.
+func addErrorClass(combined Fields, f Fields, msg interface{}) {
+	classifiersMu.RLock()
+	n := len(classifiers)
+	classifiersMu.RUnlock()
+
+	if n == 0 {
+		return
+	}
+
+	err, ok := msg.(error)
+	if !ok {
+		var key string
+		for k, v := range f {
+			if e, ok := v.(error); ok && (err == nil || k < key) {
+				err, key = e, k
+			}
+		}
+	}
+
+	if err == nil {
+		return
+	}
+
+	c, ok := classifyError(err)
+	if !ok {
+		return
+	}
+
+	add := func(k string, v interface{}) {
+		if _, ok := combined[k]; !ok {
+			combined[k] = fmt.Sprint(v)
+		}
+	}
+
+	if c.Kind != "" {
+		add("error.kind", c.Kind)
+	}
+
+	add("error.retryable", c.Retryable)
+
+	if c.HTTPStatus != 0 {
+		add("error.http_status", c.HTTPStatus)
+	}
+}
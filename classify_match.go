@@ -0,0 +1,19 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slog
+
+import (
+	"errors"
+	"reflect"
+)
+
+// errorMatcher returns a function that reports whether errors.As
+// can assign an error to a variable of the same type as target.
+func errorMatcher(target error) func(error) bool {
+	t := reflect.TypeOf(target)
+
+	return func(err error) bool {
+		return errors.As(err, reflect.New(t).Interface())
+	}
+}
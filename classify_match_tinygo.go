@@ -0,0 +1,27 @@
+//go:build tinygo
+// +build tinygo
+
+package slog
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errorMatcher returns a function that reports whether an error in
+// the chain of an error has the same type as target. Unlike errors.As,
+// which needs reflection to allocate a target of that type, it does
+// not call As methods.
+func errorMatcher(target error) func(error) bool {
+	t := fmt.Sprintf("%T", target)
+
+	return func(err error) bool {
+		for ; err != nil; err = errors.Unwrap(err) {
+			if fmt.Sprintf("%T", err) == t {
+				return true
+			}
+		}
+
+		return false
+	}
+}
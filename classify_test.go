@@ -0,0 +1,117 @@
+package slog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type classifyTestError struct{}
+
+func (*classifyTestError) Error() string { return "unavailable" }
+
+var errClassifyTestSentinel = errors.New("sentinel")
+
+func TestErrorClass(t *testing.T) {
+	t.Parallel()
+
+	RegisterErrorClass((*classifyTestError)(nil), ErrorClass{
+		Kind:       "unavailable",
+		Retryable:  true,
+		HTTPStatus: 503,
+	})
+
+	RegisterErrorClassifier(func(err error) (ErrorClass, bool) {
+		return ErrorClass{Kind: "sentinel"}, errors.Is(err, errClassifyTestSentinel)
+	})
+
+	wrapped := fmt.Errorf("calling upstream: %w", &classifyTestError{})
+
+	tests := []struct {
+		name string
+		f    Fields
+		msg  interface{}
+		expF Fields
+	}{
+		{
+			name: "message",
+			msg:  wrapped,
+			expF: Fields{
+				"error.kind":        "unavailable",
+				"error.retryable":   "true",
+				"error.http_status": "503",
+			},
+		},
+		{
+			name: "field",
+			f:    Fields{"err": errClassifyTestSentinel},
+			msg:  "failed",
+			expF: Fields{
+				"err":             map[string]interface{}{"message": "sentinel"},
+				"error.kind":      "sentinel",
+				"error.retryable": "false",
+			},
+		},
+		{
+			name: "explicit fields win",
+			f:    Fields{"err": wrapped, "error.kind": "custom"},
+			msg:  "failed",
+			expF: Fields{
+				"err":               map[string]interface{}{"message": wrapped.Error()},
+				"error.kind":        "custom",
+				"error.retryable":   "true",
+				"error.http_status": "503",
+			},
+		},
+		{
+			name: "smallest key",
+			f:    Fields{"b": wrapped, "a": errClassifyTestSentinel, "0": "not an error"},
+			msg:  "failed",
+			expF: Fields{
+				"0":               "not an error",
+				"a":               map[string]interface{}{"message": "sentinel"},
+				"b":               map[string]interface{}{"message": wrapped.Error()},
+				"error.kind":      "sentinel",
+				"error.retryable": "false",
+			},
+		},
+		{
+			name: "unknown",
+			msg:  errors.New("unknown"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			l := NewLogger(WithOutput(mw))
+			l.Errorf(test.f, test.msg)
+
+			var e event
+			if err := json.Unmarshal(mw.byt, &e); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(test.expF) != len(e.Fields) {
+				t.Fatalf(
+					"expected '%d' field(s), got '%d': %v",
+					len(test.expF),
+					len(e.Fields),
+					e.Fields,
+				)
+			}
+
+			for k := range test.expF {
+				if !reflect.DeepEqual(test.expF[k], e.Fields[k]) {
+					t.Fatalf("expected field '%v', got '%v'", test.expF[k], e.Fields[k])
+				}
+			}
+		})
+	}
+}
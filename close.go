@@ -0,0 +1,130 @@
+package slog
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrClosed is returned by Close when the Logger, or the Logger
+// it was derived from with Named or With, is already closed.
+var ErrClosed = errors.New("slog: logger is closed")
+
+// lifecycle tracks whether a Logger is closed. Loggers derived with
+// Named or With have their own lifecycle whose parent is that of the
+// Logger they were derived from.
+type lifecycle struct {
+	parent *lifecycle
+	closed int32
+
+	// mu is held for writing by the root Logger's Close and for
+	// reading while events are written, so that Close waits for
+	// writes in progress. It is only used on the root lifecycle.
+	mu sync.RWMutex
+
+	// fallbackMu serializes writes to the fallback.
+	fallbackMu sync.Mutex
+}
+
+func (lc *lifecycle) root() *lifecycle {
+	for lc.parent != nil {
+		lc = lc.parent
+	}
+
+	return lc
+}
+
+func (lc *lifecycle) isClosed() bool {
+	for ; lc != nil; lc = lc.parent {
+		if atomic.LoadInt32(&lc.closed) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithFallback sets where the Logger writes events that are logged
+// after it is closed. If w is nil, those events are discarded.
+// The default is os.Stderr.
+func WithFallback(w io.Writer) Option {
+	return func(l *Logger) {
+		if w == nil {
+			w = io.Discard
+		}
+
+		l.fallback = w
+	}
+}
+
+// Close closes the Logger. Events logged after Close are written to
+// the fallback set with WithFallback, os.Stderr by default, so that
+// they are not lost during shutdown. Close waits for events that are
+// being written to finish.
+//
+// Closing a Logger created with New or NewLogger closes its output if it is an
+// io.Closer, other than os.Stdout and os.Stderr, and closes every
+// Logger derived from it with Named or With. Closing a derived Logger
+// only closes that Logger and those derived from it, leaving the
+// shared output open.
+//
+// Closing a Logger also writes the summaries of the events it
+// aggregates, as set with Aggregate.
+//
+// Close is safe to call more than once. It returns ErrClosed if the
+// Logger, or a Logger it was derived from, is already closed.
+func (l *Logger) Close() error {
+	if l.lc.parent != nil {
+		if l.lc.isClosed() {
+			return ErrClosed
+		}
+
+		if !atomic.CompareAndSwapInt32(&l.lc.closed, 0, 1) {
+			return ErrClosed
+		}
+
+		return nil
+	}
+
+	if !l.lc.isClosed() {
+		l.flushAggregates()
+	}
+
+	l.lc.mu.Lock()
+	defer l.lc.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&l.lc.closed, 0, 1) {
+		return ErrClosed
+	}
+
+	w := l.logger.Writer()
+	if c, ok := w.(io.Closer); ok && w != os.Stdout && w != os.Stderr {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// output writes the encoded event es out, or to the fallback
+// if the Logger is closed.
+func (l *Logger) output(es string) {
+	root := l.lc.root()
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+
+	if !l.lc.isClosed() {
+		l.logger.Output(l.callDepth, es)
+		return
+	}
+
+	w := l.fallback
+	if w == nil {
+		w = os.Stderr
+	}
+
+	root.fallbackMu.Lock()
+	io.WriteString(w, es+"\n")
+	root.fallbackMu.Unlock()
+}
@@ -0,0 +1,126 @@
+package slog
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+type closeWriter struct {
+	linesWriter
+	closed int
+}
+
+func (w *closeWriter) Close() error {
+	w.closed++
+	return nil
+}
+
+func TestClose(t *testing.T) {
+	t.Parallel()
+
+	var (
+		w        = &closeWriter{}
+		fallback = &linesWriter{}
+		l        = NewLogger(WithOutput(w), WithFallback(fallback))
+		child    = l.With(Fields{"a": "b"})
+		named    = l.Named("db")
+	)
+
+	l.Info("before")
+
+	if err := named.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := named.Close(); err != ErrClosed {
+		t.Fatalf("expected '%v' closing twice, got '%v'", ErrClosed, err)
+	}
+
+	named.Info("after named close")
+	l.Info("still open")
+
+	if w.closed != 0 || len(w.lines) != 2 || len(fallback.lines) != 1 {
+		t.Fatalf(
+			"expected closing a child to leave the output open, got '%d' close(s), '%d' and '%d' line(s)",
+			w.closed,
+			len(w.lines),
+			len(fallback.lines),
+		)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Close(); err != ErrClosed {
+		t.Fatalf("expected '%v' closing twice, got '%v'", ErrClosed, err)
+	}
+
+	if err := child.Close(); err != ErrClosed {
+		t.Fatalf("expected '%v' closing a child of a closed Logger, got '%v'", ErrClosed, err)
+	}
+
+	l.Info("after close")
+	child.Info("after close")
+
+	if w.closed != 1 {
+		t.Fatalf("expected the output to be closed once, got '%d'", w.closed)
+	}
+
+	if len(w.lines) != 2 || len(fallback.lines) != 3 {
+		t.Fatalf("expected events after Close in the fallback, got '%d' and '%d' line(s)", len(w.lines), len(fallback.lines))
+	}
+
+	if !strings.Contains(fallback.lines[2], `"a":"b"`) {
+		t.Fatalf("expected the child's fields in the fallback, got '%s'", fallback.lines[2])
+	}
+}
+
+func TestCloseRace(t *testing.T) {
+	t.Parallel()
+
+	var (
+		w        = &closeWriter{}
+		fallback = &closeWriter{}
+		l        = NewLogger(WithOutput(w), WithFallback(fallback))
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Info("racing")
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		l.Close()
+	}()
+
+	wg.Wait()
+
+	if n := len(w.lines) + len(fallback.lines); n != 400 {
+		t.Fatalf("expected '%d' line(s) in total, got '%d'", 400, n)
+	}
+}
+
+func TestStrictTestingClose(t *testing.T) {
+	t.Parallel()
+
+	l := NewLogger(WithOutput(&mockWriter{}), WithStrictTesting())
+	l.Close()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected a panic logging after Close, but did not get one")
+		}
+	}()
+
+	l.Info("after close")
+}
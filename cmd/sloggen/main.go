@@ -0,0 +1,300 @@
+// Command sloggen generates typed logging functions for event structs,
+// so that critical events, such as business or audit events, always
+// have the same schema.
+//
+// Structs are annotated with a comment giving the level and the message
+// of their events:
+//
+//	//slog:event info "user logged in"
+//	type UserLogin struct {
+//		User    string
+//		IP      net.IP `slog:"ip"`
+//		Retries int
+//		Secret  string `slog:"-"`
+//	}
+//
+// For each annotated struct, sloggen generates a function named Log
+// followed by the struct's name:
+//
+//	func LogUserLogin(l *slog.Logger, e UserLogin)
+//
+// which logs the exported fields of e as fields, with keys given by their
+// slog tags or, by default, their names in snake case, such as "retries".
+// Fields tagged "-" are not logged. Strings, booleans, integers, and
+// floats are passed to Logger.LogDepth as typed slog.Fields, which are
+// formatted without reflection; other values are passed with slog.Any
+// and formatted by the Logger.
+//
+// sloggen is intended to be run by go generate:
+//
+//	//go:generate sloggen
+//
+// It reads the file named by $GOFILE, or by its argument, and writes the
+// functions to a file with the same name ending in "_slog.go".
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	path := os.Getenv("GOFILE")
+	if len(os.Args) > 1 {
+		path = os.Args[1]
+	}
+
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "usage: sloggen [file.go]")
+		os.Exit(2)
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sloggen:", err)
+		os.Exit(1)
+	}
+
+	out, err := generate(path, src)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sloggen:", err)
+		os.Exit(1)
+	}
+
+	if out == nil {
+		return
+	}
+
+	if err := os.WriteFile(strings.TrimSuffix(path, ".go")+"_slog.go", out, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "sloggen:", err)
+		os.Exit(1)
+	}
+}
+
+// annotation is the prefix of the comments that annotate event structs.
+const annotation = "//slog:event "
+
+// levels maps the levels that events may have to their constants.
+var levels = map[string]string{
+	"trace": "TraceLevel",
+	"debug": "DebugLevel",
+	"info":  "InfoLevel",
+	"warn":  "WarnLevel",
+	"error": "ErrorLevel",
+}
+
+// event is an annotated struct.
+type event struct {
+	name    string
+	level   string
+	message string
+	fields  []field
+}
+
+type field struct {
+	key, name, typ string
+}
+
+// generate returns the source of the logging functions of the structs
+// annotated in src, or nil if there are none.
+func generate(filename string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []event
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+
+			doc := ts.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+
+			e, ok, err := parseEvent(fset, doc, ts)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				events = append(events, e)
+			}
+		}
+	}
+
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by sloggen from %s. DO NOT EDIT.\n\n", filepath.Base(filename))
+	fmt.Fprintf(&b, "package %s\n\nimport \"github.com/safe-waters/slog\"\n", f.Name.Name)
+
+	for _, e := range events {
+		fmt.Fprintf(&b, "\n// Log%s logs e at the %s level with the message %q.\n", e.name, e.level, e.message)
+		fmt.Fprintf(&b, "func Log%s(l *slog.Logger, e %s) {\n", e.name, e.name)
+		fmt.Fprintf(&b, "\tl.LogDepth(1, slog.%s, %q", levels[e.level], e.message)
+		if len(e.fields) > 0 {
+			b.WriteString(",\n")
+			for _, fd := range e.fields {
+				fmt.Fprintf(&b, "\t\t%s,\n", fieldExpr(fd))
+			}
+			b.WriteString("\t")
+		}
+		b.WriteString(")\n}\n")
+	}
+
+	return format.Source(b.Bytes())
+}
+
+// parseEvent parses the struct ts if doc annotates it.
+func parseEvent(fset *token.FileSet, doc *ast.CommentGroup, ts *ast.TypeSpec) (event, bool, error) {
+	if doc == nil {
+		return event{}, false, nil
+	}
+
+	var line *ast.Comment
+	for _, c := range doc.List {
+		if strings.HasPrefix(c.Text, annotation) {
+			line = c
+		}
+	}
+
+	if line == nil {
+		return event{}, false, nil
+	}
+
+	errorf := func(format string, args ...interface{}) (event, bool, error) {
+		return event{}, false, fmt.Errorf("%s: %s", fset.Position(line.Pos()), fmt.Sprintf(format, args...))
+	}
+
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok {
+		return errorf("%s is not a struct", ts.Name.Name)
+	}
+
+	args := strings.TrimSpace(strings.TrimPrefix(line.Text, annotation))
+	i := strings.IndexByte(args, ' ')
+	if i < 0 {
+		return errorf("expected a level and a quoted message")
+	}
+
+	e := event{name: ts.Name.Name, level: args[:i]}
+	if _, ok := levels[e.level]; !ok {
+		return errorf("unknown level %q", e.level)
+	}
+
+	msg, err := strconv.Unquote(strings.TrimSpace(args[i+1:]))
+	if err != nil {
+		return errorf("message must be quoted: %v", err)
+	}
+	e.message = msg
+
+	for _, fd := range st.Fields.List {
+		key := ""
+		if fd.Tag != nil {
+			tag, err := strconv.Unquote(fd.Tag.Value)
+			if err == nil {
+				key = reflect.StructTag(tag).Get("slog")
+			}
+		}
+
+		if key == "-" {
+			continue
+		}
+
+		for _, name := range fd.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			k := key
+			if k == "" {
+				k = snakeCase(name.Name)
+			}
+
+			e.fields = append(e.fields, field{key: k, name: name.Name, typ: typeString(fd.Type)})
+		}
+	}
+
+	return e, true, nil
+}
+
+func typeString(e ast.Expr) string {
+	if id, ok := e.(*ast.Ident); ok {
+		return id.Name
+	}
+
+	return ""
+}
+
+// fieldExpr returns the expression of the typed slog.Field of fd,
+// which formats its value without reflection if its type is basic.
+func fieldExpr(fd field) string {
+	v := "e." + fd.name
+
+	var ctor string
+	switch fd.typ {
+	case "string":
+		ctor = "slog.String(%q, " + v + ")"
+	case "bool":
+		ctor = "slog.Bool(%q, " + v + ")"
+	case "int64":
+		ctor = "slog.Int64(%q, " + v + ")"
+	case "int":
+		ctor = "slog.Int(%q, " + v + ")"
+	case "int8", "int16", "int32":
+		ctor = "slog.Int64(%q, int64(" + v + "))"
+	case "uint64":
+		ctor = "slog.Uint64(%q, " + v + ")"
+	case "uint", "uint8", "uint16", "uint32", "uintptr":
+		ctor = "slog.Uint64(%q, uint64(" + v + "))"
+	case "float64":
+		ctor = "slog.Float64(%q, " + v + ")"
+	case "float32":
+		ctor = "slog.Float64(%q, float64(" + v + "))"
+	default:
+		ctor = "slog.Any(%q, " + v + ")"
+	}
+
+	return fmt.Sprintf(ctor, fd.key)
+}
+
+// snakeCase converts a Go name, such as "UserID", to snake case,
+// such as "user_id".
+func snakeCase(s string) string {
+	rs := []rune(s)
+
+	var b strings.Builder
+	for i, r := range rs {
+		if unicode.IsUpper(r) {
+			// Start a word at an upper-case letter that follows a lower-case
+			// letter or digit, or that starts a word after an acronym.
+			if i > 0 && (!unicode.IsUpper(rs[i-1]) ||
+				(i+1 < len(rs) && unicode.IsLower(rs[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
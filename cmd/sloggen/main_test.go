@@ -0,0 +1,123 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	src := "package audit\n\n" +
+		"import \"net\"\n\n" +
+		"//go:generate sloggen\n\n" +
+		"// UserLogin is logged when a user logs in.\n" +
+		"//slog:event info \"user logged in\"\n" +
+		"type UserLogin struct {\n" +
+		"\tUser    string\n" +
+		"\tIP      net.IP `slog:\"ip\"`\n" +
+		"\tRetries int\n" +
+		"\tMFA     bool\n" +
+		"\tSecret  string `slog:\"-\"`\n" +
+		"\tsession string\n" +
+		"}\n\n" +
+		"type unannotated struct{}\n"
+
+	exp := "// Code generated by sloggen from audit.go. DO NOT EDIT.\n\n" +
+		"package audit\n\n" +
+		"import \"github.com/safe-waters/slog\"\n\n" +
+		"// LogUserLogin logs e at the info level with the message \"user logged in\".\n" +
+		"func LogUserLogin(l *slog.Logger, e UserLogin) {\n" +
+		"\tl.LogDepth(1, slog.InfoLevel, \"user logged in\",\n" +
+		"\t\tslog.String(\"user\", e.User),\n" +
+		"\t\tslog.Any(\"ip\", e.IP),\n" +
+		"\t\tslog.Int(\"retries\", e.Retries),\n" +
+		"\t\tslog.Bool(\"mfa\", e.MFA),\n" +
+		"\t)\n" +
+		"}\n"
+
+	out, err := generate("dir/audit.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out) != exp {
+		t.Fatalf("expected:\n%s\ngot:\n%s", exp, out)
+	}
+}
+
+func TestGenerateNoEvents(t *testing.T) {
+	out, err := generate("a.go", []byte("package a\n\ntype A struct{}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out != nil {
+		t.Fatalf("expected no output, got:\n%s", out)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		exp  string
+	}{
+		{
+			name: "not a struct",
+			src:  "//slog:event info \"a\"\ntype A int",
+			exp:  "A is not a struct",
+		},
+		{
+			name: "unknown level",
+			src:  "//slog:event loud \"a\"\ntype A struct{}",
+			exp:  "unknown level \"loud\"",
+		},
+		{
+			name: "no message",
+			src:  "//slog:event info\ntype A struct{}",
+			exp:  "expected a level and a quoted message",
+		},
+		{
+			name: "unquoted message",
+			src:  "//slog:event info a\ntype A struct{}",
+			exp:  "message must be quoted",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := generate("a.go", []byte("package a\n\n"+test.src+"\n"))
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+
+			if !strings.Contains(err.Error(), test.exp) {
+				t.Fatalf("expected an error containing '%s', got '%v'", test.exp, err)
+			}
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in, exp string
+	}{
+		{in: "User", exp: "user"},
+		{in: "UserID", exp: "user_id"},
+		{in: "HTTPStatus", exp: "http_status"},
+		{in: "RetryCount2", exp: "retry_count2"},
+		{in: "IP", exp: "ip"},
+	}
+
+	for _, test := range tests {
+		if got := snakeCase(test.in); got != test.exp {
+			t.Fatalf("expected '%s' for '%s', got '%s'", test.exp, test.in, got)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Command slogvet reports misuse of slog's idioms in Go packages,
+// as described by the slogvet package:
+//
+//	slogvet ./...
+//
+// Its arguments are directories, files, or directories followed by
+// "/..." to check them recursively. The files of each directory are
+// type-checked by package, importing their dependencies from the
+// export data that "go list -export" builds, so slogvet must run in
+// the module of the packages it checks.
+// It exits with status 1 if it reports any problem.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/safe-waters/slog/slogvet"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: slogvet [packages]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	n, err := run(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "slogvet:", err)
+		os.Exit(2)
+	}
+
+	if n > 0 {
+		os.Exit(1)
+	}
+}
+
+// run prints the diagnostics of the Go files named by args
+// and returns how many there are.
+func run(args []string) (int, error) {
+	files, err := goFiles(args)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		fset = token.NewFileSet()
+		pkgs = map[string][]*ast.File{}
+		keys []string
+	)
+
+	for _, path := range files {
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return 0, err
+		}
+
+		// Files are grouped by directory and package name, so that
+		// external test packages are checked on their own.
+		k := filepath.Dir(path) + " " + f.Name.Name
+		if _, ok := pkgs[k]; !ok {
+			keys = append(keys, k)
+		}
+		pkgs[k] = append(pkgs[k], f)
+	}
+
+	var (
+		conf = types.Config{
+			Importer: importer.ForCompiler(fset, "gc", exportData),
+			// Type errors, such as imports that cannot be found, leave
+			// some calls unresolved, which are then not checked.
+			Error: func(error) {},
+		}
+		n int
+	)
+
+	for _, k := range keys {
+		info := &types.Info{Uses: map[*ast.Ident]types.Object{}}
+		conf.Check(pkgs[k][0].Name.Name, fset, pkgs[k], info)
+
+		for _, f := range pkgs[k] {
+			for _, d := range slogvet.CheckFile(fset, f, info) {
+				fmt.Println(d)
+				n++
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// exportData opens the export data of the package with the import
+// path path, as built by the go command.
+func exportData(path string) (io.ReadCloser, error) {
+	out, err := exec.Command("go", "list", "-export", "-f", "{{.Export}}", path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list %s: %w", path, err)
+	}
+
+	return os.Open(strings.TrimSpace(string(out)))
+}
+
+// goFiles returns the Go files named by args.
+func goFiles(args []string) ([]string, error) {
+	var files []string
+
+	for _, arg := range args {
+		dir, recursive := strings.TrimSuffix(arg, "/..."), strings.HasSuffix(arg, "/...")
+		if arg == "..." {
+			dir, recursive = ".", true
+		}
+
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, dir)
+			continue
+		}
+
+		err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				if path == dir {
+					return nil
+				}
+				if !recursive || skipDir(d.Name()) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if strings.HasSuffix(path, ".go") {
+				files = append(files, path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// skipDir reports whether the go tool ignores directories named name.
+func skipDir(name string) bool {
+	return name == "testdata" || name == "vendor" ||
+		strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
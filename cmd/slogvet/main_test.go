@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"a.go": `package a
+import "github.com/safe-waters/slog"
+var _ = slog.Fields{"level": 1}`,
+		"sub/b.go": `package b
+import "github.com/safe-waters/slog"
+var _ = slog.Fields{"time": 1}`,
+		"testdata/c.go": `package c
+import "github.com/safe-waters/slog"
+var _ = slog.Fields{"file": 1}`,
+	}
+
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		args []string
+		exp  int
+	}{
+		{args: []string{dir}, exp: 1},
+		{args: []string{dir + "/..."}, exp: 2},
+		{args: []string{filepath.Join(dir, "testdata", "c.go")}, exp: 1},
+	}
+
+	for _, test := range tests {
+		n, err := run(test.args)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != test.exp {
+			t.Fatalf("expected '%d' diagnostic(s) for '%v', got '%d'", test.exp, test.args, n)
+		}
+	}
+
+	if _, err := run([]string{filepath.Join(dir, "missing")}); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
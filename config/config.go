@@ -0,0 +1,291 @@
+// Package config builds slog Loggers from configuration files,
+// so that operators can tune logging without recompiling.
+//
+// A configuration is a JSON document such as:
+//
+//	{
+//		"level": "info",
+//		"outputs": [
+//			{"sink": "stdout"},
+//			{
+//				"sink": "file",
+//				"params": {"path": "/var/log/errors.log"},
+//				"filter": "level>=error"
+//			}
+//		],
+//		"format": "json",
+//		"sampling": 1000,
+//		"fields": {"service": "api"},
+//		"hooks": [{"name": "identity", "params": {"provider": "host"}}],
+//		"routes": [
+//			{
+//				"where": "fields.tenant == \"acme\"",
+//				"sink": "file",
+//				"params": {"path": "/var/log/acme.log"}
+//			}
+//		]
+//	}
+//
+// Sinks and hooks are created by name from the slog registry,
+// so integrations registered with slog.RegisterSink and
+// slog.RegisterHook can be configured too. The sinks "stdout",
+// "stderr", and "file" are built in, and the "identity" hook is
+// registered by importing the identity package. Likewise, the filters
+// and transforms of outputs can be registered by name with
+// RegisterFilter and RegisterTransform.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/safe-waters/slog"
+)
+
+// Config describes a Logger. The zero Config describes a Logger
+// with the defaults of slog.NewLogger.
+type Config struct {
+	// Level is the minimum level, as accepted by slog.ParseLevel.
+	Level string `json:"level,omitempty"`
+
+	// Outputs are the sinks that events are written to.
+	// If there are none, events are written to os.Stdout.
+	Outputs []Output `json:"outputs,omitempty"`
+
+	// Format is the encoding of events.
+	// Only "json" is supported, which is the default.
+	Format string `json:"format,omitempty"`
+
+	// Sampling is the target number of events per second,
+	// as for slog.Logger.SetSampling. Zero turns sampling off.
+	Sampling int `json:"sampling,omitempty"`
+
+	// Fields are permanent fields that appear with every event.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+
+	// Hooks are the hooks that fire for every event.
+	Hooks []Hook `json:"hooks,omitempty"`
+
+	// Routes send the events that match them to their own sinks,
+	// instead of to the outputs.
+	Routes []Route `json:"routes,omitempty"`
+
+	// Development turns development mode on.
+	Development bool `json:"development,omitempty"`
+}
+
+// Output names a registered sink and its parameters, and optionally
+// wires a pipeline of a filter, an encoder, and transforms in front
+// of it, so that different outputs can receive different events.
+type Output struct {
+	Sink   string            `json:"sink"`
+	Params map[string]string `json:"params,omitempty"`
+
+	// Filter selects the events written to the output. It is the name
+	// of a Filter registered with RegisterFilter, or an expression such
+	// as "level>=warn" or "level>=info && fields.tenant==acme".
+	Filter string `json:"filter,omitempty"`
+
+	// Encoder is the encoding of events.
+	// Only "json" is supported, which is the default.
+	Encoder string `json:"encoder,omitempty"`
+
+	// Transforms name Transforms registered with RegisterTransform,
+	// which rewrite events, in order, before they are written.
+	Transforms []string `json:"transforms,omitempty"`
+}
+
+// Route sends the events that match Where to a registered sink,
+// for example to give each tenant or team its own destination.
+// Routes are evaluated in order for every event, and an event goes
+// to the first Route it matches only.
+type Route struct {
+	// Where is a filter, as for Output.Filter,
+	// such as `fields.tenant == "acme"`.
+	Where string `json:"where"`
+
+	Sink   string            `json:"sink"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// Hook names a registered hook and its parameters.
+type Hook struct {
+	Name   string            `json:"name"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// Load reads a JSON Config from r and builds its Logger.
+// Unknown keys are an error, so that typos do not go unnoticed.
+func Load(r io.Reader) (*slog.Logger, error) {
+	var c Config
+
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&c); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	return c.Build()
+}
+
+// LoadFile reads a JSON Config from the file named path
+// and builds its Logger.
+func LoadFile(path string) (*slog.Logger, error) {
+	byt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	return Load(bytes.NewReader(byt))
+}
+
+// Build returns the Logger that c describes. If c is invalid,
+// the sinks that Build already created are closed.
+func (c Config) Build() (_ *slog.Logger, err error) {
+	opts := []slog.Option{}
+
+	var sinks []io.Writer
+	defer func() {
+		if err != nil {
+			closeSinks(sinks...)
+		}
+	}()
+
+	if c.Level != "" {
+		lv, err := slog.ParseLevel(c.Level)
+		if err != nil {
+			return nil, fmt.Errorf("config: level: %w", err)
+		}
+		opts = append(opts, slog.WithLevel(lv))
+	}
+
+	if c.Format != "" && !strings.EqualFold(c.Format, "json") {
+		return nil, fmt.Errorf("config: format: unsupported format %q", c.Format)
+	}
+
+	if c.Sampling < 0 {
+		return nil, fmt.Errorf("config: sampling: negative target %d", c.Sampling)
+	}
+
+	var out io.Writer
+	if len(c.Outputs) > 0 {
+		ws := make([]io.Writer, 0, len(c.Outputs))
+		for _, o := range c.Outputs {
+			w, err := slog.NewSink(o.Sink, o.Params)
+			if err != nil {
+				return nil, fmt.Errorf("config: outputs: %w", err)
+			}
+			sinks = append(sinks, w)
+
+			if w, err = o.pipeline(w); err != nil {
+				return nil, fmt.Errorf("config: outputs: %s: %w", o.Sink, err)
+			}
+			ws = append(ws, w)
+		}
+
+		out = ws[0]
+		if len(ws) > 1 {
+			out = &multiWriter{ws: ws}
+		}
+	}
+
+	if len(c.Routes) > 0 {
+		if out == nil {
+			out = os.Stdout
+		}
+
+		rw := &routeWriter{fallback: out}
+		for _, r := range c.Routes {
+			f, err := parseFilter(r.Where)
+			if err != nil {
+				return nil, fmt.Errorf("config: routes: %w", err)
+			}
+
+			w, err := slog.NewSink(r.Sink, r.Params)
+			if err != nil {
+				return nil, fmt.Errorf("config: routes: %w", err)
+			}
+			sinks = append(sinks, w)
+			rw.routes = append(rw.routes, route{match: f, w: w})
+		}
+		out = rw
+	}
+
+	if out != nil {
+		opts = append(opts, slog.WithOutput(out))
+	}
+
+	if len(c.Fields) > 0 {
+		opts = append(opts, slog.WithFields(slog.Fields(c.Fields)))
+	}
+
+	hooks := make([]slog.Hook, 0, len(c.Hooks))
+	for _, h := range c.Hooks {
+		hook, err := slog.NewHook(h.Name, h.Params)
+		if err != nil {
+			return nil, fmt.Errorf("config: hooks: %w", err)
+		}
+		hooks = append(hooks, hook)
+	}
+
+	l := slog.NewLogger(opts...)
+
+	for _, h := range hooks {
+		l.AddHook(h)
+	}
+
+	l.SetSampling(c.Sampling)
+	l.SetDevelopment(c.Development)
+
+	return l, nil
+}
+
+// closeSinks closes the sinks in ws that are io.Closers, except
+// os.Stdout and os.Stderr, and returns the first error.
+func closeSinks(ws ...io.Writer) error {
+	var first error
+
+	for _, w := range ws {
+		if w == os.Stdout || w == os.Stderr {
+			continue
+		}
+
+		if c, ok := w.(io.Closer); ok {
+			if err := c.Close(); err != nil && first == nil {
+				first = err
+			}
+		}
+	}
+
+	return first
+}
+
+// multiWriter writes to every writer in ws, like io.MultiWriter,
+// and closes them when it is closed, so that closing the Logger
+// closes the sinks of its outputs.
+type multiWriter struct {
+	ws []io.Writer
+}
+
+func (m *multiWriter) Write(b []byte) (int, error) {
+	for _, w := range m.ws {
+		n, err := w.Write(b)
+		if err != nil {
+			return n, err
+		}
+		if n != len(b) {
+			return n, io.ErrShortWrite
+		}
+	}
+
+	return len(b), nil
+}
+
+// Close closes the writers of m.
+func (m *multiWriter) Close() error {
+	return closeSinks(m.ws...)
+}
@@ -0,0 +1,250 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/safe-waters/slog"
+)
+
+type linesWriter struct{ lines []string }
+
+func (w *linesWriter) Write(p []byte) (n int, err error) {
+	w.lines = append(w.lines, strings.TrimSpace(string(p)))
+	return len(p), nil
+}
+
+var sinkN int64
+
+// registerSink registers w as a sink with a name that is unique
+// across test runs, because names cannot be registered twice.
+func registerSink(w io.Writer) string {
+	name := fmt.Sprintf("config-test-%d", atomic.AddInt64(&sinkN, 1))
+	slog.RegisterSink(name, func(map[string]string) (io.Writer, error) {
+		return w, nil
+	})
+
+	return name
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a = &linesWriter{}
+		b = &linesWriter{}
+	)
+
+	cfg := fmt.Sprintf(`{
+		"level": "warn",
+		"outputs": [{"sink": %q}, {"sink": %q}],
+		"format": "json",
+		"fields": {"service": "api"}
+	}`, registerSink(a), registerSink(b))
+
+	l, err := Load(strings.NewReader(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.Info("dropped")
+	l.Warn("logged")
+
+	for _, w := range []*linesWriter{a, b} {
+		if len(w.lines) != 1 {
+			t.Fatalf("expected '%d' line(s) per output, got '%d'", 1, len(w.lines))
+		}
+
+		var e struct {
+			Fields  map[string]string `json:"fields"`
+			Message string            `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(w.lines[0]), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Message != "logged" || e.Fields["service"] != "api" {
+			t.Fatalf("expected message '%s' with field '%s', got '%v'", "logged", "api", e)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir  = t.TempDir()
+		out  = filepath.Join(dir, "out.log")
+		path = filepath.Join(dir, "config.json")
+	)
+
+	cfg := fmt.Sprintf(`{"outputs": [{"sink": "file", "params": {"path": %q}}]}`, out)
+	if err := os.WriteFile(path, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.Info("hello")
+
+	byt, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(byt), `"message":"hello"`) {
+		t.Fatalf("expected the event in the file, got '%s'", byt)
+	}
+
+	if _, err := LoadFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Fatal("expected an error for a missing file, but got none")
+	}
+}
+
+type closeWriter struct {
+	linesWriter
+	closed bool
+}
+
+func (w *closeWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestBuildClosesSinksOnError(t *testing.T) {
+	t.Parallel()
+
+	var (
+		out   = &closeWriter{}
+		route = &closeWriter{}
+	)
+
+	c := Config{
+		Outputs: []Output{{Sink: registerSink(out)}},
+		Routes:  []Route{{Where: "level>=error", Sink: registerSink(route)}},
+		Hooks:   []Hook{{Name: "missing"}},
+	}
+
+	if _, err := c.Build(); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	if !out.closed || !route.closed {
+		t.Fatalf("expected the sinks to be closed, got '%v' and '%v'", out.closed, route.closed)
+	}
+}
+
+func TestCloseClosesSinks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		used  int
+		build func(sinks []string) Config
+	}{
+		{
+			name: "filter",
+			used: 1,
+			build: func(sinks []string) Config {
+				return Config{Outputs: []Output{{Sink: sinks[0], Filter: "level>=warn"}}}
+			},
+		},
+		{
+			name: "outputs",
+			used: 2,
+			build: func(sinks []string) Config {
+				return Config{Outputs: []Output{{Sink: sinks[0]}, {Sink: sinks[1]}}}
+			},
+		},
+		{
+			name: "routes",
+			used: 2,
+			build: func(sinks []string) Config {
+				return Config{
+					Outputs: []Output{{Sink: sinks[0]}},
+					Routes:  []Route{{Where: "level>=error", Sink: sinks[1]}},
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				ws    = []*closeWriter{{}, {}}
+				sinks = []string{registerSink(ws[0]), registerSink(ws[1])}
+			)
+
+			l, err := test.build(sinks).Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := l.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, w := range ws[:test.used] {
+				if !w.closed {
+					t.Fatalf("expected sink '%d' to be closed, but it was not", i)
+				}
+			}
+		})
+	}
+}
+
+func TestCloseClosesFiles(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "warn.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := Config{Outputs: []Output{{Sink: registerSink(f), Filter: "level>=warn"}}}.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.Write([]byte("x")); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("expected the file to be closed, got '%v'", err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		`{"level": "loud"}`,
+		`{"format": "logfmt"}`,
+		`{"sampling": -1}`,
+		`{"outputs": [{"sink": "missing"}]}`,
+		`{"hooks": [{"name": "missing"}]}`,
+		`{"levle": "info"}`,
+		`{`,
+	}
+
+	for _, cfg := range tests {
+		if _, err := Load(strings.NewReader(cfg)); err == nil {
+			t.Fatalf("expected an error for '%s', but got none", cfg)
+		}
+	}
+}
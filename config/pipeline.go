@@ -0,0 +1,311 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/safe-waters/slog"
+)
+
+// Event is an event decoded from the JSON that a Logger writes,
+// with its "_metadata", "fields", and "message".
+type Event map[string]interface{}
+
+// Level returns the level of e, from its metadata.
+func (e Event) Level() slog.Level {
+	m, _ := e["_metadata"].(map[string]interface{})
+	s, _ := m["level"].(string)
+
+	return slog.Level(s)
+}
+
+// Field returns the field of e named k, if it is a string.
+func (e Event) Field(k string) (string, bool) {
+	f, _ := e["fields"].(map[string]interface{})
+	s, ok := f[k].(string)
+
+	return s, ok
+}
+
+// Filter reports whether an event is written to an output.
+type Filter func(e Event) bool
+
+// Transform rewrites an event before it is written to an output.
+type Transform func(e Event)
+
+var (
+	registryMu sync.RWMutex
+	filters    = map[string]Filter{}
+	transforms = map[string]Transform{}
+)
+
+// RegisterFilter makes a Filter available by name to the "filter"
+// of outputs. It is intended to be called from an init function.
+//
+// RegisterFilter panics if name is already registered or fn is nil.
+func RegisterFilter(name string, fn Filter) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if fn == nil {
+		panic("config: RegisterFilter filter is nil")
+	}
+
+	if _, ok := filters[name]; ok {
+		panic("config: RegisterFilter called twice for filter " + name)
+	}
+
+	filters[name] = fn
+}
+
+// RegisterTransform makes a Transform available by name to the
+// "transforms" of outputs. It is intended to be called from an init
+// function.
+//
+// RegisterTransform panics if name is already registered or fn is nil.
+func RegisterTransform(name string, fn Transform) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if fn == nil {
+		panic("config: RegisterTransform transform is nil")
+	}
+
+	if _, ok := transforms[name]; ok {
+		panic("config: RegisterTransform called twice for transform " + name)
+	}
+
+	transforms[name] = fn
+}
+
+// pipeline returns w wrapped to apply the filter, encoder,
+// and transforms of o.
+func (o Output) pipeline(w io.Writer) (io.Writer, error) {
+	if o.Encoder != "" && !strings.EqualFold(o.Encoder, "json") {
+		return nil, fmt.Errorf("unsupported encoder %q", o.Encoder)
+	}
+
+	p := &pipeWriter{w: w}
+
+	if o.Filter != "" {
+		f, err := parseFilter(o.Filter)
+		if err != nil {
+			return nil, err
+		}
+		p.filter = f
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, name := range o.Transforms {
+		t, ok := transforms[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown transform %q", name)
+		}
+		p.transforms = append(p.transforms, t)
+	}
+
+	if p.filter == nil && p.transforms == nil {
+		return w, nil
+	}
+
+	return p, nil
+}
+
+// parseFilter returns the registered Filter named s or, failing that,
+// the Filter of the expression s. An expression is one or more
+// conditions joined by "&&", each comparing "level", "message",
+// or "fields.<key>" to a value, such as "level>=warn" or
+// "fields.tenant==acme". Values may be quoted, as in
+// `fields.tenant == "acme"`. Levels can be compared with ==, !=, <, <=,
+// >, and >=, and other values with == and !=.
+func parseFilter(s string) (Filter, error) {
+	registryMu.RLock()
+	f, ok := filters[s]
+	registryMu.RUnlock()
+
+	if ok {
+		return f, nil
+	}
+
+	var conds []Filter
+	for rest := s; ; {
+		i, _ := indexUnquoted(rest, []string{"&&"})
+		if i < 0 {
+			i = len(rest)
+		}
+
+		f, err := parseCondition(strings.TrimSpace(rest[:i]))
+		if err != nil {
+			return nil, fmt.Errorf("filter %q: %w", s, err)
+		}
+		conds = append(conds, f)
+
+		if i == len(rest) {
+			break
+		}
+		rest = rest[i+len("&&"):]
+	}
+
+	return func(e Event) bool {
+		for _, f := range conds {
+			if !f(e) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// ops are the comparison operators of conditions, longest first,
+// so that "<=" is not mistaken for "<".
+var ops = []string{">=", "<=", "==", "!=", ">", "<"}
+
+func parseCondition(c string) (Filter, error) {
+	i, op := indexUnquoted(c, ops)
+	if i < 0 {
+		return nil, fmt.Errorf("no operator in condition %q", c)
+	}
+
+	var (
+		lhs = strings.TrimSpace(c[:i])
+		rhs = strings.TrimSpace(c[i+len(op):])
+	)
+
+	if strings.HasPrefix(rhs, `"`) {
+		s, err := strconv.Unquote(rhs)
+		if err != nil {
+			return nil, fmt.Errorf("value %s: %w", rhs, err)
+		}
+		rhs = s
+	}
+
+	if lhs == "level" {
+		return levelCondition(op, rhs)
+	}
+
+	var get func(e Event) (string, bool)
+	switch {
+	case lhs == "message":
+		get = func(e Event) (string, bool) {
+			s, ok := e["message"].(string)
+			return s, ok
+		}
+	case strings.HasPrefix(lhs, "fields.") && len(lhs) > len("fields."):
+		k := lhs[len("fields."):]
+		get = func(e Event) (string, bool) { return e.Field(k) }
+	default:
+		return nil, fmt.Errorf("unknown operand %q", lhs)
+	}
+
+	switch op {
+	case "==":
+		return func(e Event) bool {
+			v, ok := get(e)
+			return ok && v == rhs
+		}, nil
+	case "!=":
+		return func(e Event) bool {
+			v, ok := get(e)
+			return !ok || v != rhs
+		}, nil
+	default:
+		return nil, fmt.Errorf("operator %q only applies to levels", op)
+	}
+}
+
+// indexUnquoted returns the index of the first of seps in s, outside
+// of double-quoted values, and the separator found there. Separators
+// that start at the same index are tried in order. It returns -1 if
+// s contains none of seps outside of quotes.
+func indexUnquoted(s string, seps []string) (int, string) {
+	quoted := false
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quoted && s[i] == '\\':
+			i++
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted:
+			for _, sep := range seps {
+				if strings.HasPrefix(s[i:], sep) {
+					return i, sep
+				}
+			}
+		}
+	}
+
+	return -1, ""
+}
+
+func levelCondition(op, rhs string) (Filter, error) {
+	lv, err := slog.ParseLevel(rhs)
+	if err != nil {
+		return nil, err
+	}
+
+	want, _ := lv.Severity()
+
+	cmp := map[string]func(s int) bool{
+		">=": func(s int) bool { return s >= want },
+		"<=": func(s int) bool { return s <= want },
+		"==": func(s int) bool { return s == want },
+		"!=": func(s int) bool { return s != want },
+		">":  func(s int) bool { return s > want },
+		"<":  func(s int) bool { return s < want },
+	}[op]
+
+	return func(e Event) bool {
+		s, ok := e.Level().Severity()
+		return ok && cmp(s)
+	}, nil
+}
+
+// pipeWriter filters and transforms the events written to it
+// before writing them to w.
+type pipeWriter struct {
+	w          io.Writer
+	filter     Filter
+	transforms []Transform
+}
+
+// Close closes the writer of p.
+func (p *pipeWriter) Close() error {
+	return closeSinks(p.w)
+}
+
+func (p *pipeWriter) Write(b []byte) (int, error) {
+	var e Event
+	if err := json.Unmarshal(b, &e); err != nil {
+		return p.w.Write(b)
+	}
+
+	if p.filter != nil && !p.filter(e) {
+		return len(b), nil
+	}
+
+	if p.transforms == nil {
+		return p.w.Write(b)
+	}
+
+	for _, t := range p.transforms {
+		t(e)
+	}
+
+	out, err := json.Marshal(e)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := p.w.Write(append(out, '\n')); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
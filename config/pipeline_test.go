@@ -0,0 +1,158 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/safe-waters/slog"
+)
+
+var pipelineN int64
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	n := atomic.AddInt64(&pipelineN, 1)
+	var (
+		filter    = fmt.Sprintf("pipeline-test-filter-%d", n)
+		transform = fmt.Sprintf("pipeline-test-transform-%d", n)
+	)
+
+	RegisterFilter(filter, func(e Event) bool {
+		v, _ := e.Field("tenant")
+		return v == "acme"
+	})
+	RegisterTransform(transform, func(e Event) {
+		e["message"] = strings.ToUpper(e["message"].(string))
+	})
+
+	tests := []struct {
+		name       string
+		filter     string
+		transforms []string
+		exp        []string
+	}{
+		{name: "no pipeline", exp: []string{"info acme", "warn other", "error acme"}},
+		{name: "level", filter: "level>=warn", exp: []string{"warn other", "error acme"}},
+		{name: "level equal", filter: "level == warning", exp: []string{"warn other"}},
+		{name: "level below", filter: "level<error", exp: []string{"info acme", "warn other"}},
+		{name: "field", filter: "fields.tenant==acme", exp: []string{"info acme", "error acme"}},
+		{name: "field not equal", filter: "fields.tenant!=acme", exp: []string{"warn other"}},
+		{name: "message", filter: "message==warn other", exp: []string{"warn other"}},
+		{
+			name:   "conjunction",
+			filter: "level>=warn && fields.tenant==acme",
+			exp:    []string{"error acme"},
+		},
+		{name: "registered filter", filter: filter, exp: []string{"info acme", "error acme"}},
+		{
+			name:       "transform",
+			filter:     "level>info",
+			transforms: []string{transform},
+			exp:        []string{"WARN OTHER", "ERROR ACME"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := &linesWriter{}
+			cfg := Config{Outputs: []Output{{
+				Sink:       registerSink(w),
+				Filter:     test.filter,
+				Encoder:    "json",
+				Transforms: test.transforms,
+			}}}
+
+			l, err := cfg.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			l.Infof(slog.Fields{"tenant": "acme"}, "info acme")
+			l.Warnf(slog.Fields{"tenant": "other"}, "warn other")
+			l.Errorf(slog.Fields{"tenant": "acme"}, "error acme")
+
+			if len(w.lines) != len(test.exp) {
+				t.Fatalf("expected '%d' line(s), got '%d': %v", len(test.exp), len(w.lines), w.lines)
+			}
+
+			for i, exp := range test.exp {
+				if !strings.Contains(w.lines[i], fmt.Sprintf(`"message":%q`, exp)) {
+					t.Fatalf("expected message '%s' in '%s'", exp, w.lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseFilterQuoted(t *testing.T) {
+	t.Parallel()
+
+	e := Event{
+		"_metadata": map[string]interface{}{"level": "warn"},
+		"fields":    map[string]interface{}{"q": `a>=b && "c"`},
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		exp    bool
+	}{
+		{name: "operators in value", filter: `fields.q == "a>=b && \"c\""`, exp: true},
+		{name: "different value", filter: `fields.q == "a>=b"`},
+		{name: "conjunction", filter: `fields.q != "x && y" && level>=warn`, exp: true},
+		{name: "conjunction false", filter: `fields.q != "x && y" && level>=error`},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := parseFilter(test.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := f(e); got != test.exp {
+				t.Fatalf("expected '%v', got '%v'", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestPipelineErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		o    Output
+	}{
+		{name: "encoder", o: Output{Encoder: "logfmt"}},
+		{name: "no operator", o: Output{Filter: "level"}},
+		{name: "unknown level", o: Output{Filter: "level>=loud"}},
+		{name: "unknown operand", o: Output{Filter: "host==a"}},
+		{name: "ordered field", o: Output{Filter: "fields.n>1"}},
+		{name: "transform", o: Output{Transforms: []string{"pipeline-test-missing"}}},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			test.o.Sink = registerSink(&linesWriter{})
+			if _, err := (Config{Outputs: []Output{test.o}}).Build(); err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type route struct {
+	match Filter
+	w     io.Writer
+}
+
+// routeWriter writes each event to the writer of the first route
+// it matches, or to fallback if it matches none.
+type routeWriter struct {
+	routes   []route
+	fallback io.Writer
+}
+
+func (rw *routeWriter) Write(b []byte) (int, error) {
+	var e Event
+	if err := json.Unmarshal(b, &e); err == nil {
+		for _, r := range rw.routes {
+			if r.match(e) {
+				return r.w.Write(b)
+			}
+		}
+	}
+
+	return rw.fallback.Write(b)
+}
+
+// Close closes the writers of the routes and the fallback.
+func (rw *routeWriter) Close() error {
+	ws := make([]io.Writer, 0, len(rw.routes)+1)
+	for _, r := range rw.routes {
+		ws = append(ws, r.w)
+	}
+
+	return closeSinks(append(ws, rw.fallback)...)
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/safe-waters/slog"
+)
+
+func TestRoutes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		acme    = &linesWriter{}
+		errs    = &linesWriter{}
+		outputs = &linesWriter{}
+	)
+
+	cfg := Config{
+		Outputs: []Output{{Sink: registerSink(outputs)}},
+		Routes: []Route{
+			{Where: `fields.tenant == "acme"`, Sink: registerSink(acme)},
+			{Where: "level>=error", Sink: registerSink(errs)},
+		},
+	}
+
+	l, err := cfg.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.Errorf(slog.Fields{"tenant": "acme"}, "acme error")
+	l.Infof(slog.Fields{"tenant": "acme"}, "acme info")
+	l.Errorf(slog.Fields{"tenant": "other"}, "other error")
+	l.Infof(slog.Fields{"tenant": "other"}, "other info")
+
+	tests := []struct {
+		name string
+		w    *linesWriter
+		exp  []string
+	}{
+		{name: "acme", w: acme, exp: []string{"acme error", "acme info"}},
+		{name: "errors", w: errs, exp: []string{"other error"}},
+		{name: "outputs", w: outputs, exp: []string{"other info"}},
+	}
+
+	for _, test := range tests {
+		if len(test.w.lines) != len(test.exp) {
+			t.Fatalf("%s: expected '%d' line(s), got '%d': %v", test.name, len(test.exp), len(test.w.lines), test.w.lines)
+		}
+
+		for i, exp := range test.exp {
+			if !strings.Contains(test.w.lines[i], fmt.Sprintf(`"message":%q`, exp)) {
+				t.Fatalf("%s: expected message '%s' in '%s'", test.name, exp, test.w.lines[i])
+			}
+		}
+	}
+}
+
+func TestRouteErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		r    Route
+	}{
+		{name: "where", r: Route{Where: "tenant", Sink: "stdout"}},
+		{name: "quote", r: Route{Where: `fields.tenant == "acme`, Sink: "stdout"}},
+		{name: "sink", r: Route{Where: "level>=warn", Sink: "route-test-missing"}},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := (Config{Routes: []Route{test.r}}).Build(); err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+		})
+	}
+}
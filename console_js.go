@@ -0,0 +1,68 @@
+//go:build js && wasm
+// +build js,wasm
+
+package slog
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"syscall/js"
+)
+
+// ConsoleWriter writes events to the browser console, using
+// the console method that matches each event's level:
+// console.debug for trace, console.info for info, console.warn
+// for warn, and console.error for the levels above. Custom levels
+// use the method of the closest less severe built-in level.
+//
+// It is only available when GOOS=js and GOARCH=wasm.
+type ConsoleWriter struct{}
+
+// NewConsoleWriter returns a ConsoleWriter.
+func NewConsoleWriter() *ConsoleWriter {
+	return &ConsoleWriter{}
+}
+
+// Write writes one encoded event to the console.
+func (w *ConsoleWriter) Write(p []byte) (int, error) {
+	line := strings.TrimSuffix(string(p), "\n")
+
+	var e struct {
+		Metadata struct {
+			Level string `json:"level"`
+		} `json:"_metadata"`
+	}
+	json.Unmarshal(p, &e)
+
+	js.Global().Get("console").Call(consoleMethod(e.Metadata.Level), line)
+
+	return len(p), nil
+}
+
+// consoleMethods maps levels to console methods. Custom levels map
+// like the closest less severe built-in level.
+var consoleMethods = SeverityTable{
+	TraceLevel: "debug",
+	DebugLevel: "debug",
+	InfoLevel:  "info",
+	WarnLevel:  "warn",
+	ErrorLevel: "error",
+	PanicLevel: "error",
+	FatalLevel: "error",
+}
+
+func consoleMethod(lv string) string {
+	m, ok := consoleMethods.Severity(Level(lv))
+	if !ok {
+		return "log"
+	}
+
+	return m.(string)
+}
+
+func init() {
+	RegisterSink("console", func(map[string]string) (io.Writer, error) {
+		return NewConsoleWriter(), nil
+	})
+}
@@ -0,0 +1,240 @@
+package slog
+
+import (
+	"context"
+	"os"
+	"sync"
+)
+
+// ContextExtractor returns fields taken from a context.Context,
+// such as a tenant ID, a user ID, or the deadline. It returns nil
+// if ctx holds none of the values it extracts.
+type ContextExtractor func(ctx context.Context) Fields
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   []ContextExtractor
+)
+
+type contextKey struct{}
+
+type minLevelKey struct{}
+
+// NewContext returns a copy of ctx that carries l, so that
+// a request-scoped Logger, for example one made with With,
+// can travel through call chains without being passed explicitly.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the Logger carried by ctx. If ctx carries none,
+// it returns a Logger that writes to the same output as the default
+// Logger, with a copy of its settings. If ctx carries baggage, set with
+// WithBaggage, the Logger is derived with With to add it.
+func FromContext(ctx context.Context) *Logger {
+	l, ok := ctx.Value(contextKey{}).(*Logger)
+	if !ok || l == nil {
+		// The default Logger is created for the package-level
+		// functions, so its call depth is one too many here.
+		l = defaultLogger.child()
+		l.callDepth = DefaultCallDepth
+	}
+
+	if b := BaggageFromContext(ctx); b != nil {
+		l = l.With(b)
+	}
+
+	return l
+}
+
+// WithMinLevel returns a copy of ctx that sets the minimum level of the
+// events logged with it, by methods such as InfoCtx and LogCtx, for
+// example to log one request at the debug level without changing the
+// level of the Logger:
+//
+//	if r.Header.Get("X-Debug") == "1" {
+//		ctx = slog.WithMinLevel(ctx, slog.DebugLevel)
+//	}
+//
+// The level of ctx takes priority over those set with SetLevel,
+// SetAtomicLevel, and SetNamedLevel.
+func WithMinLevel(ctx context.Context, lv Level) context.Context {
+	return context.WithValue(ctx, minLevelKey{}, lv)
+}
+
+// enabledCtx reports whether the Logger logs events at lv
+// with ctx, whose minimum level, if any, takes priority.
+func (l *Logger) enabledCtx(ctx context.Context, lv Level) bool {
+	if ctx != nil {
+		if min, ok := ctx.Value(minLevelKey{}).(Level); ok {
+			return atLeast(lv, min)
+		}
+	}
+
+	return l.enabled(lv)
+}
+
+// RegisterContextExtractor registers fn to add fields to every event
+// logged with a context, by methods such as InfoCtx and LogCtx,
+// for example:
+//
+//	slog.RegisterContextExtractor(func(ctx context.Context) slog.Fields {
+//		if id, ok := ctx.Value(tenantKey{}).(string); ok {
+//			return slog.Fields{"tenant_id": id}
+//		}
+//		return nil
+//	})
+//
+// Extractors run in the order they were registered, and later
+// extractors replace fields of the same name. It is intended
+// to be called from an init function.
+//
+// RegisterContextExtractor panics if fn is nil.
+func RegisterContextExtractor(fn ContextExtractor) {
+	if fn == nil {
+		panic("slog: RegisterContextExtractor extractor is nil")
+	}
+
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+
+	extractors = append(extractors, fn)
+}
+
+// contextFields returns the fields extracted from ctx combined
+// with f, whose fields take priority.
+func contextFields(ctx context.Context, f Fields) Fields {
+	extractorsMu.RLock()
+	fns := extractors
+	extractorsMu.RUnlock()
+
+	if ctx == nil || len(fns) == 0 {
+		return f
+	}
+
+	var c Fields
+	for _, fn := range fns {
+		ef := fn(ctx)
+		if len(ef) == 0 {
+			continue
+		}
+
+		if c == nil {
+			c = make(Fields, len(ef)+len(f))
+		}
+		for k, v := range ef {
+			c[k] = v
+		}
+	}
+
+	if c == nil {
+		return f
+	}
+
+	for k, v := range f {
+		c[k] = v
+	}
+
+	return c
+}
+
+// TraceCtx calls the default Logger's TraceCtx method.
+func TraceCtx(ctx context.Context, msg interface{}) {
+	defaultLogger.TraceCtx(ctx, msg)
+}
+
+// DebugCtx calls the default Logger's DebugCtx method.
+func DebugCtx(ctx context.Context, msg interface{}) {
+	defaultLogger.DebugCtx(ctx, msg)
+}
+
+// InfoCtx calls the default Logger's InfoCtx method.
+func InfoCtx(ctx context.Context, msg interface{}) {
+	defaultLogger.InfoCtx(ctx, msg)
+}
+
+// WarnCtx calls the default Logger's WarnCtx method.
+func WarnCtx(ctx context.Context, msg interface{}) {
+	defaultLogger.WarnCtx(ctx, msg)
+}
+
+// ErrorCtx calls the default Logger's ErrorCtx method.
+func ErrorCtx(ctx context.Context, msg interface{}) {
+	defaultLogger.ErrorCtx(ctx, msg)
+}
+
+// PanicCtx calls the default Logger's PanicCtx method.
+func PanicCtx(ctx context.Context, msg interface{}) {
+	defaultLogger.PanicCtx(ctx, msg)
+}
+
+// FatalCtx calls the default Logger's FatalCtx method.
+func FatalCtx(ctx context.Context, msg interface{}) {
+	defaultLogger.FatalCtx(ctx, msg)
+}
+
+// LogCtx calls the default Logger's LogCtx method.
+func LogCtx(ctx context.Context, lv Level, f Fields, msg interface{}) {
+	defaultLogger.LogCtx(ctx, lv, f, msg)
+}
+
+// TraceCtx logs a message, with the fields extracted from ctx,
+// at the trace level.
+func (l *Logger) TraceCtx(ctx context.Context, msg interface{}) {
+	l.logCtx(ctx, TraceLevel, nil, msg)
+}
+
+// DebugCtx logs a message, with the fields extracted from ctx,
+// at the debug level.
+func (l *Logger) DebugCtx(ctx context.Context, msg interface{}) {
+	l.logCtx(ctx, DebugLevel, nil, msg)
+}
+
+// InfoCtx logs a message, with the fields extracted from ctx,
+// at the info level.
+func (l *Logger) InfoCtx(ctx context.Context, msg interface{}) {
+	l.logCtx(ctx, InfoLevel, nil, msg)
+}
+
+// WarnCtx logs a message, with the fields extracted from ctx,
+// at the warn level.
+func (l *Logger) WarnCtx(ctx context.Context, msg interface{}) {
+	l.logCtx(ctx, WarnLevel, nil, msg)
+}
+
+// ErrorCtx logs a message, with the fields extracted from ctx,
+// at the error level.
+func (l *Logger) ErrorCtx(ctx context.Context, msg interface{}) {
+	l.logCtx(ctx, ErrorLevel, nil, msg)
+}
+
+// PanicCtx logs a message, with the fields extracted from ctx,
+// at the panic level and then panics with a *PanicError
+// that holds the message.
+func (l *Logger) PanicCtx(ctx context.Context, msg interface{}) {
+	l.logCtx(ctx, PanicLevel, nil, msg)
+}
+
+// FatalCtx logs a message, with the fields extracted from ctx,
+// at the fatal level followed by os.Exit(1).
+func (l *Logger) FatalCtx(ctx context.Context, msg interface{}) {
+	l.logCtx(ctx, FatalLevel, nil, msg)
+	os.Exit(1)
+}
+
+// LogCtx logs fields and a message at lv, like Log, adding
+// the fields extracted from ctx. Keys in f replace
+// extracted fields of the same name.
+func (l *Logger) LogCtx(ctx context.Context, lv Level, f Fields, msg interface{}) {
+	l.logCtx(ctx, lv, f, msg)
+
+	if lv == FatalLevel {
+		os.Exit(1)
+	}
+}
+
+// logCtx is like log, adding the fields extracted from ctx
+// and honoring its minimum level.
+func (l *Logger) logCtx(ctx context.Context, lv Level, f Fields, msg interface{}) {
+	l.logEvent(ctx, 0, lv, contextFields(ctx, f), nil, msg)
+}
@@ -0,0 +1,136 @@
+package slog
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	t.Parallel()
+
+	l := NewLogger(WithOutput(&mockWriter{})).With(Fields{"request_id": "1"})
+
+	ctx := NewContext(context.Background(), l)
+	if got := FromContext(ctx); got != l {
+		t.Fatal("expected the Logger carried by the context, but got another")
+	}
+
+	d := FromContext(context.Background())
+	if d == nil || d.logger != defaultLogger.logger {
+		t.Fatal("expected a Logger sharing the default output, but got another")
+	}
+
+	if d.rec != defaultLogger.rec {
+		t.Fatal("expected a Logger sharing the default Summary, but got another")
+	}
+
+	if d.callDepth != DefaultCallDepth {
+		t.Fatalf("expected call depth '%d', got '%d'", DefaultCallDepth, d.callDepth)
+	}
+}
+
+type tenantKey struct{}
+
+func TestContextExtractor(t *testing.T) {
+	t.Parallel()
+
+	RegisterContextExtractor(func(ctx context.Context) Fields {
+		if id, ok := ctx.Value(tenantKey{}).(string); ok {
+			return Fields{"tenant_id": id, "source": "context"}
+		}
+		return nil
+	})
+
+	var (
+		w   = &linesWriter{}
+		l   = NewLogger(WithOutput(w))
+		ctx = context.WithValue(context.Background(), tenantKey{}, "acme")
+	)
+
+	l.InfoCtx(ctx, "hello")
+	l.LogCtx(ctx, WarnLevel, Fields{"source": "call"}, "hello")
+	l.InfoCtx(context.Background(), "hello")
+
+	exp := []Fields{
+		{"tenant_id": "acme", "source": "context"},
+		{"tenant_id": "acme", "source": "call"},
+		{},
+	}
+
+	if len(w.lines) != len(exp) {
+		t.Fatalf("expected '%d' line(s), got '%d'", len(exp), len(w.lines))
+	}
+
+	for i, line := range w.lines {
+		var e event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(e.Fields) != len(exp[i]) {
+			t.Fatalf("expected fields '%v', got '%v'", exp[i], e.Fields)
+		}
+
+		for k, v := range exp[i] {
+			if e.Fields[k] != v {
+				t.Fatalf("expected field '%s' to be '%v', got '%v'", k, v, e.Fields[k])
+			}
+		}
+
+		if !strings.HasPrefix(e.Metadata["file"].(string), "context_test.go:") {
+			t.Fatalf("expected file '%s', got '%s'", "context_test.go", e.Metadata["file"])
+		}
+	}
+}
+
+func TestRegisterContextExtractorPanics(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected a panic, but did not get one")
+		}
+	}()
+
+	RegisterContextExtractor(nil)
+}
+
+func TestWithMinLevel(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w))
+	l.SetLevel(WarnLevel)
+
+	debug := WithMinLevel(context.Background(), DebugLevel)
+	quiet := WithMinLevel(context.Background(), ErrorLevel)
+
+	l.DebugCtx(debug, "logged")
+	l.LogCtx(debug, InfoLevel, nil, "logged")
+	l.TraceCtx(debug, "dropped")
+	l.DebugCtx(context.Background(), "dropped")
+	l.Debug("dropped")
+	l.WarnCtx(quiet, "dropped")
+	l.ErrorCtx(quiet, "logged")
+
+	if len(w.lines) != 3 {
+		t.Fatalf("expected '%d' line(s), got '%d': %v", 3, len(w.lines), w.lines)
+	}
+
+	for _, line := range w.lines {
+		var e event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Message != "logged" {
+			t.Fatalf("expected message '%s', got '%v'", "logged", e.Message)
+		}
+
+		if !strings.HasPrefix(e.Metadata["file"].(string), "context_test.go:") {
+			t.Fatalf("expected file '%s', got '%s'", "context_test.go", e.Metadata["file"])
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package slog
+
+import (
+	"os"
+	"sort"
+)
+
+// SetFieldDefaults declares fields that every event must have, so that
+// dashboards and alerts that rely on them never see gaps. The fields
+// of defaults are added to every event that does not have them after
+// its own fields and the permanent fields are added. For example:
+//
+//	l.SetFieldDefaults(slog.Fields{
+//		"env":    "unknown",
+//		"region": slog.EnvValue("REGION", "unknown"),
+//	})
+//
+// Default values are formatted for every event, so a Lazy value
+// is called every time it is used.
+// If defaults is empty, no defaults are added, which is the default.
+func (l *Logger) SetFieldDefaults(defaults Fields) {
+	l.mu.Lock()
+	old := describeKeys(defaultKeys(l.cfg.defaults))
+
+	l.cfg.defaults = nil
+	if len(defaults) > 0 {
+		l.cfg.defaults = make(Fields, len(defaults))
+		for k, v := range defaults {
+			l.cfg.defaults[k] = v
+		}
+	}
+
+	new := describeKeys(defaultKeys(l.cfg.defaults))
+	l.mu.Unlock()
+
+	l.configChanged("field_defaults", old, new)
+}
+
+// EnvValue returns a Lazy value of the environment variable named name,
+// or of fallback if the variable is empty, for use as a field default.
+func EnvValue(name, fallback string) Lazy {
+	return func() interface{} {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+		return fallback
+	}
+}
+
+// defaultKeys returns the sorted keys of defaults,
+// or nil if defaults is nil.
+func defaultKeys(defaults Fields) []string {
+	if defaults == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(defaults))
+	for k := range defaults {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// addDefaults adds the defaults that f does not have to f.
+func addDefaults(f Fields, defaults Fields) {
+	for k, v := range defaults {
+		if _, ok := f[k]; !ok {
+			f[k] = formatField(v)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package slog
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestFieldDefaults(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("SLOG_TEST_FIELD_DEFAULTS_REGION", "eu-west-1")
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw), WithFields(Fields{"service": "api"}))
+	l.SetFieldDefaults(Fields{
+		"env":     "unknown",
+		"service": "unknown",
+		"region":  EnvValue("SLOG_TEST_FIELD_DEFAULTS_REGION", "unknown"),
+		"zone":    EnvValue("SLOG_TEST_FIELD_DEFAULTS_UNSET", "unknown"),
+	})
+
+	tests := []struct {
+		name string
+		f    Fields
+		exp  map[string]string
+	}{
+		{
+			name: "absent",
+			exp:  map[string]string{"env": "unknown", "service": "api", "region": "eu-west-1", "zone": "unknown"},
+		},
+		{
+			name: "present",
+			f:    Fields{"env": "prod", "zone": "b"},
+			exp:  map[string]string{"env": "prod", "service": "api", "region": "eu-west-1", "zone": "b"},
+		},
+	}
+
+	for _, test := range tests {
+		l.Infof(test.f, "hello")
+
+		var e struct {
+			Fields map[string]string `json:"fields"`
+		}
+		if err := json.Unmarshal(mw.byt, &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(e.Fields) != len(test.exp) {
+			t.Fatalf("%s: expected fields '%v', got '%v'", test.name, test.exp, e.Fields)
+		}
+		for k, v := range test.exp {
+			if e.Fields[k] != v {
+				t.Fatalf("%s: expected field '%s' to be '%s', got '%s'", test.name, k, v, e.Fields[k])
+			}
+		}
+	}
+
+	l.SetFieldDefaults(nil)
+	l.Info("hello")
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+	if len(e.Fields) != 1 {
+		t.Fatalf("expected '%d' field(s) without defaults, got '%v'", 1, e.Fields)
+	}
+}
@@ -0,0 +1,101 @@
+package slog
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// maxDeprecationSites bounds the number of call sites of deprecated
+// APIs that are remembered, so that each is only reported once.
+const maxDeprecationSites = 10000
+
+// DeprecationOption describes a deprecation logged with Deprecated.
+type DeprecationOption func(f Fields)
+
+// Until gives the date or version in which a deprecated API
+// will be removed, logged as "deprecation.until".
+func Until(when string) DeprecationOption {
+	return func(f Fields) {
+		f["until"] = when
+	}
+}
+
+// Instead gives the API to use instead of a deprecated API,
+// logged as "deprecation.instead".
+func Instead(api string) DeprecationOption {
+	return func(f Fields) {
+		f["instead"] = api
+	}
+}
+
+// Deprecated calls the default Logger's Deprecated method.
+func Deprecated(api string, f Fields, opts ...DeprecationOption) {
+	defaultLogger.Deprecated(api, f, opts...)
+}
+
+// Deprecated logs a warning that the deprecated api was used, so that
+// library authors can report deprecations consistently. It is intended
+// to be called from the deprecated API itself:
+//
+//	func Connect(addr string) (*Conn, error) {
+//		slog.Deprecated("Connect", nil, slog.Until("v2.0.0"), slog.Instead("Dial"))
+//		// ...
+//	}
+//
+// The event has f as its fields, and the group "deprecation" with the
+// "api", the "caller" of the deprecated API, and the details given by
+// opts. Its file is also the caller of the deprecated API. It is only
+// logged the first time the deprecated API is called from each call
+// site, so that deprecations in hot paths do not flood the logs. Call
+// sites are remembered by the Logger and the Loggers derived from it,
+// once their event is written.
+func (l *Logger) Deprecated(api string, f Fields, opts ...DeprecationOption) {
+	// The caller of the deprecated API is one frame above
+	// the caller of this method.
+	caller := "?"
+	if _, file, line, ok := runtime.Caller(l.callDepth - 1); ok {
+		caller = fmt.Sprintf("%s:%d", file[strings.LastIndex(file, "/")+1:], line)
+	}
+
+	key := api + "\x00" + caller
+	if l.deprecationReported(key) {
+		return
+	}
+
+	d := Fields{"api": api, "caller": caller}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	c := make(Fields, len(f)+1)
+	for k, v := range f {
+		c[k] = v
+	}
+	c["deprecation"] = d
+
+	// logEvent is called directly, instead of through log, so that
+	// the file of the event is the caller of the deprecated API.
+	if es := l.logEvent(nil, 0, WarnLevel, c, nil, "deprecated API used: "+api); es != "" {
+		l.reportDeprecation(key)
+	}
+}
+
+// deprecationReported reports whether the call site key was reported,
+// or whether too many call sites were to remember more.
+func (l *Logger) deprecationReported(key string) bool {
+	r := l.rec
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deprecations[key] || len(r.deprecations) >= maxDeprecationSites
+}
+
+// reportDeprecation remembers that the call site key was reported.
+func (l *Logger) reportDeprecation(key string) {
+	r := l.rec
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deprecations[key] = true
+}
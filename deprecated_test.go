@@ -0,0 +1,85 @@
+package slog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+var deprecatedN int64
+
+func deprecatedTestConnect(l *Logger, api string) {
+	l.Deprecated(api, Fields{"addr": "localhost"}, Until("v2.0.0"), Instead("Dial"))
+}
+
+func TestDeprecated(t *testing.T) {
+	t.Parallel()
+
+	api := fmt.Sprintf("Connect%d", atomic.AddInt64(&deprecatedN, 1))
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w))
+
+	// Call sites are only remembered once their event is written.
+	l.SetLevel(ErrorLevel)
+	deprecatedTestConnect(l, api)
+	l.SetLevel(TraceLevel)
+
+	for i := 0; i < 3; i++ {
+		deprecatedTestConnect(l, api)
+	}
+	deprecatedTestConnect(l, api)
+
+	if len(w.lines) != 2 {
+		t.Fatalf("expected '%d' line(s), once per call site, got '%d'", 2, len(w.lines))
+	}
+
+	var e struct {
+		Metadata map[string]string `json:"_metadata"`
+		Fields   struct {
+			Addr        string            `json:"addr"`
+			Deprecation map[string]string `json:"deprecation"`
+		} `json:"fields"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(w.lines[0]), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Metadata["level"] != string(WarnLevel) || e.Message != "deprecated API used: "+api {
+		t.Fatalf("expected a warning for '%s', got '%s'", api, w.lines[0])
+	}
+
+	if e.Fields.Addr != "localhost" ||
+		e.Fields.Deprecation["api"] != api ||
+		e.Fields.Deprecation["until"] != "v2.0.0" ||
+		e.Fields.Deprecation["instead"] != "Dial" {
+		t.Fatalf("expected deprecation fields, got '%s'", w.lines[0])
+	}
+
+	if !strings.HasPrefix(e.Fields.Deprecation["caller"], "deprecated_test.go:") ||
+		e.Fields.Deprecation["caller"] != e.Metadata["file"] {
+		t.Fatalf(
+			"expected the caller of the deprecated API, got '%s' and '%s'",
+			e.Fields.Deprecation["caller"],
+			e.Metadata["file"],
+		)
+	}
+
+	// Derived Loggers share the call sites of their Logger,
+	// and other Loggers have their own.
+	w2 := &linesWriter{}
+	for _, dl := range []*Logger{l, l.Named("child"), NewLogger(WithOutput(w2))} {
+		deprecatedTestConnect(dl, api)
+	}
+
+	if len(w.lines) != 3 || len(w2.lines) != 1 {
+		t.Fatalf(
+			"expected call sites to be remembered per Logger, got '%d' and '%d' line(s)",
+			len(w.lines),
+			len(w2.lines),
+		)
+	}
+}
@@ -0,0 +1,108 @@
+package slog
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// dupThreshold is the number of structurally identical events
+	// a call site may log within dupWindow before it is reported
+	// as noisy in development mode.
+	dupThreshold = 100
+	dupWindow    = time.Second
+
+	// maxDupSites bounds the number of call sites tracked
+	// in development mode.
+	maxDupSites = 10000
+)
+
+// SetDevelopment turns development mode on or off. It is off by default.
+//
+// In development mode, the Logger watches for call sites that log
+// structurally identical events (the same message, apart from
+// numbers and IDs, with the same field keys) at a high frequency.
+// The first time a call site does so, the Logger logs a one-time
+// warning suggesting sampling or a lower level for that call site.
+func (l *Logger) SetDevelopment(enabled bool) {
+	l.mu.Lock()
+	old := l.cfg.dupes != nil
+
+	if !enabled {
+		l.cfg.dupes = nil
+	} else if l.cfg.dupes == nil {
+		l.cfg.dupes = &dupDetector{sites: make(map[string]*dupSite)}
+	}
+	l.mu.Unlock()
+
+	l.configChanged("development", old, enabled)
+}
+
+type dupDetector struct {
+	mu    sync.Mutex
+	sites map[string]*dupSite
+}
+
+type dupSite struct {
+	start    time.Time
+	count    int
+	reported bool
+}
+
+func (d *dupDetector) observe(l *Logger, e *Event) {
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	key := e.File + "\x00" +
+		volatileRe.ReplaceAllString(e.Message, "?") + "\x00" +
+		strings.Join(keys, "\x00")
+
+	d.mu.Lock()
+
+	s, ok := d.sites[key]
+	if !ok {
+		if len(d.sites) >= maxDupSites {
+			d.mu.Unlock()
+			return
+		}
+		s = &dupSite{start: e.Time}
+		d.sites[key] = s
+	}
+
+	if s.reported {
+		d.mu.Unlock()
+		return
+	}
+
+	if e.Time.Sub(s.start) > dupWindow {
+		s.start = e.Time
+		s.count = 0
+	}
+	s.count++
+
+	report := s.count >= dupThreshold
+	if report {
+		s.reported = true
+	}
+
+	d.mu.Unlock()
+
+	if report {
+		l.write(&Event{
+			Level: WarnLevel,
+			File:  e.File,
+			Time:  l.now(),
+			Fields: Fields{
+				"call_site":  e.File,
+				"message":    e.Message,
+				"suggestion": "consider sampling this call site or lowering its level",
+			},
+			Message: "noisy call site: structurally identical events logged at a high frequency",
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package slog
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type linesWriter struct{ lines []string }
+
+func (w *linesWriter) Write(p []byte) (n int, err error) {
+	w.lines = append(w.lines, strings.TrimSpace(string(p)))
+	return len(p), nil
+}
+
+func TestDevelopmentDuplicates(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w))
+	l.SetDevelopment(true)
+
+	for i := 0; i < 2*dupThreshold; i++ {
+		l.Infof(Fields{"i": i}, "processing item "+strings.Repeat("1", i%3+1))
+	}
+
+	var advisories []event
+	for _, line := range w.lines {
+		var e event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Metadata["level"] == string(WarnLevel) {
+			advisories = append(advisories, e)
+		}
+	}
+
+	if len(advisories) != 1 {
+		t.Fatalf("expected '%d' advisory, got '%d'", 1, len(advisories))
+	}
+
+	if !strings.HasPrefix(advisories[0].Fields["call_site"].(string), "dev_test.go:") {
+		t.Fatalf(
+			"expected call_site in '%s', got '%s'",
+			"dev_test.go",
+			advisories[0].Fields["call_site"],
+		)
+	}
+}
+
+func TestDevelopmentOff(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w))
+
+	for i := 0; i < 2*dupThreshold; i++ {
+		l.Info("processing")
+	}
+
+	if len(w.lines) != 2*dupThreshold {
+		t.Fatalf("expected '%d' line(s), got '%d'", 2*dupThreshold, len(w.lines))
+	}
+}
@@ -0,0 +1,68 @@
+package slog
+
+// Diff returns the differences between the events a and b.
+// Each key of the result names an attribute that differs, such as
+// "message" or "level", and maps to Fields holding the value in a
+// under "a" and the value in b under "b". Differences in Fields and
+// Metadata are nested under "fields" and "metadata", keyed by field.
+// A value that is missing from one of the events is omitted from
+// its pair. Diff returns nil if the events are equal.
+func Diff(a, b Event) Fields {
+	d := Fields{}
+
+	diffValue(d, "id", a.ID, b.ID)
+	diffValue(d, "level", a.Level, b.Level)
+	diffValue(d, "file", a.File, b.File)
+	diffValue(d, "message", a.Message, b.Message)
+	diffValue(d, "fingerprint", a.Fingerprint, b.Fingerprint)
+
+	if !a.Time.Equal(b.Time) {
+		d["time"] = Fields{"a": a.Time, "b": b.Time}
+	}
+
+	if f := diffFields(a.Fields, b.Fields); f != nil {
+		d["fields"] = f
+	}
+
+	if f := diffFields(a.Metadata, b.Metadata); f != nil {
+		d["metadata"] = f
+	}
+
+	if len(d) == 0 {
+		return nil
+	}
+
+	return d
+}
+
+func diffValue(d Fields, k string, a, b interface{}) {
+	if !equal(a, b) {
+		d[k] = Fields{"a": a, "b": b}
+	}
+}
+
+func diffFields(a, b Fields) Fields {
+	d := Fields{}
+
+	for k, av := range a {
+		bv, ok := b[k]
+		if !ok {
+			d[k] = Fields{"a": av}
+			continue
+		}
+
+		diffValue(d, k, av, bv)
+	}
+
+	for k, bv := range b {
+		if _, ok := a[k]; !ok {
+			d[k] = Fields{"b": bv}
+		}
+	}
+
+	if len(d) == 0 {
+		return nil
+	}
+
+	return d
+}
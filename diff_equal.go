@@ -0,0 +1,10 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slog
+
+import "reflect"
+
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
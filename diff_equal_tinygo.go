@@ -0,0 +1,48 @@
+//go:build tinygo
+// +build tinygo
+
+package slog
+
+import "fmt"
+
+// equal reports whether a and b are equal without reflection.
+// Groups and lists are compared element by element, and other
+// values by their type and Go syntax.
+func equal(a, b interface{}) bool {
+	switch av := a.(type) {
+	case Fields:
+		bv, ok := b.(Fields)
+		return ok && equalGroups(av, bv)
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		return ok && equalGroups(av, bv)
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return fmt.Sprintf("%T %#v", a, a) == fmt.Sprintf("%T %#v", b, b)
+}
+
+func equalGroups(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, av := range a {
+		bv, ok := b[k]
+		if !ok || !equal(av, bv) {
+			return false
+		}
+	}
+
+	return true
+}
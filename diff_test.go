@@ -0,0 +1,92 @@
+package slog
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	base := Event{
+		ID:       "1",
+		Level:    InfoLevel,
+		File:     "main.go:1",
+		Time:     now,
+		Fields:   Fields{"user": "1", "region": "eu"},
+		Message:  "hello",
+		Metadata: Fields{"fingerprint": "abc"},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(e *Event)
+		exp    Fields
+	}{
+		{
+			name:   "equal",
+			modify: func(e *Event) {},
+			exp:    nil,
+		},
+		{
+			name: "time in another location",
+			modify: func(e *Event) {
+				e.Time = now.UTC()
+			},
+			exp: nil,
+		},
+		{
+			name: "message and level",
+			modify: func(e *Event) {
+				e.Message = "bye"
+				e.Level = WarnLevel
+			},
+			exp: Fields{
+				"message": Fields{"a": "hello", "b": "bye"},
+				"level":   Fields{"a": InfoLevel, "b": WarnLevel},
+			},
+		},
+		{
+			name: "fields",
+			modify: func(e *Event) {
+				e.Fields = Fields{"user": "2", "attempt": "3"}
+			},
+			exp: Fields{
+				"fields": Fields{
+					"user":    Fields{"a": "1", "b": "2"},
+					"region":  Fields{"a": "eu"},
+					"attempt": Fields{"b": "3"},
+				},
+			},
+		},
+		{
+			name: "time and metadata",
+			modify: func(e *Event) {
+				e.Time = now.Add(time.Second)
+				e.Metadata = nil
+			},
+			exp: Fields{
+				"time":     Fields{"a": now, "b": now.Add(time.Second)},
+				"metadata": Fields{"fingerprint": Fields{"a": "abc"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := base.Clone()
+			test.modify(b)
+
+			if d := Diff(base, *b); !reflect.DeepEqual(d, test.exp) {
+				t.Fatalf("expected diff '%v', got '%v'", test.exp, d)
+			}
+		})
+	}
+}
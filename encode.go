@@ -0,0 +1,140 @@
+package slog
+
+import (
+	"fmt"
+)
+
+// encodedKinds has the bit 1<<k set for each fieldKind k whose
+// values have an encoder, so that typed Fields of other kinds
+// are formatted without looking one up.
+var encodedKinds uint32
+
+// maxValuerDepth bounds the number of LogValue calls made to
+// resolve one value, in case LogValue returns a Valuer.
+const maxValuerDepth = 10
+
+// Valuer is implemented by types that control their own representation
+// in logs, for example to redact their secrets:
+//
+//	func (u User) LogValue() interface{} {
+//		return slog.Fields{"id": u.ID, "email": "REDACTED"}
+//	}
+//
+// Field values and messages that implement Valuer are replaced with
+// the result of LogValue before they are formatted, so LogValue may
+// return Fields to log a group. Valuers take priority over
+// registered encoders.
+type Valuer interface {
+	LogValue() interface{}
+}
+
+// Lazy is a field value that is computed only if its event is logged,
+// so that expensive diagnostics are not computed for events that are
+// filtered out by level or sampling:
+//
+//	l.Tracef(slog.Fields{"heap": slog.Lazy(heapStats)}, "gc finished")
+//
+// Field values of type func() interface{} are treated as Lazy too.
+type Lazy func() interface{}
+
+// LogValue calls f.
+func (f Lazy) LogValue() interface{} {
+	return f()
+}
+
+// resolve replaces v with its LogValue while it is a Valuer
+// or a func() interface{}.
+func resolve(v interface{}) interface{} {
+	for i := 0; i < maxValuerDepth; i++ {
+		switch lv := v.(type) {
+		case Valuer:
+			v = lv.LogValue()
+		case func() interface{}:
+			v = lv()
+		default:
+			return v
+		}
+	}
+
+	return v
+}
+
+// resolveFields returns f with its values resolved, so that Valuers,
+// which may be Lazy, are called once per event. f is returned as is
+// if it has none.
+func resolveFields(f Fields) Fields {
+	var r Fields
+
+	for k, v := range f {
+		switch v.(type) {
+		case Valuer, func() interface{}:
+		default:
+			continue
+		}
+
+		if r == nil {
+			r = make(Fields, len(f))
+			for k, v := range f {
+				r[k] = v
+			}
+		}
+		r[k] = resolve(v)
+	}
+
+	if r == nil {
+		return f
+	}
+
+	return r
+}
+
+// formatField formats the value of a field. Groups of fields are
+// formatted recursively, errors as a group with their "message",
+// and other values with formatValue.
+func formatField(v interface{}) interface{} {
+	v = resolve(v)
+
+	var g map[string]interface{}
+
+	switch v := v.(type) {
+	case Fields:
+		g = v
+	case map[string]interface{}:
+		g = v
+	case error:
+		return Fields{"message": formatValue(v)}
+	default:
+		return formatValue(v)
+	}
+
+	f := make(Fields, len(g))
+	for k, v := range g {
+		f[k] = formatField(v)
+	}
+
+	return f
+}
+
+// formatValue formats v as a string for logging.
+//
+// Values of types with a registered encoder are formatted with it.
+// Values that have no meaningful text form, which are channels,
+// functions, and unsafe pointers, are formatted as a placeholder
+// of their type, such as "<func()>", rather than as an address.
+func formatValue(v interface{}) string {
+	v = resolve(v)
+
+	if v == nil {
+		return "nil"
+	}
+
+	if s, ok := encode(v); ok {
+		return s
+	}
+
+	if s, ok := placeholder(v); ok {
+		return s
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,148 @@
+package slog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+type encodeTestStruct struct {
+	A int
+	B string
+}
+
+type encodeTestStringer struct{}
+
+func (encodeTestStringer) String() string { return "stringer" }
+
+func TestFormatValue(t *testing.T) {
+	t.Parallel()
+
+	var (
+		i   = 1
+		ch  = make(chan int)
+		rch = make(<-chan string)
+	)
+
+	tests := []struct {
+		name string
+		v    interface{}
+		exp  string
+	}{
+		{name: "nil", v: nil, exp: "nil"},
+		{name: "bool", v: true, exp: "true"},
+		{name: "int", v: -1, exp: "-1"},
+		{name: "int8", v: int8(-8), exp: "-8"},
+		{name: "int16", v: int16(-16), exp: "-16"},
+		{name: "int32", v: int32(-32), exp: "-32"},
+		{name: "int64", v: int64(-64), exp: "-64"},
+		{name: "uint", v: uint(1), exp: "1"},
+		{name: "uint8", v: uint8(8), exp: "8"},
+		{name: "uint16", v: uint16(16), exp: "16"},
+		{name: "uint32", v: uint32(32), exp: "32"},
+		{name: "uint64", v: uint64(64), exp: "64"},
+		{name: "uintptr", v: uintptr(7), exp: "7"},
+		{name: "float32", v: float32(1.5), exp: "1.5"},
+		{name: "float64", v: 2.5, exp: "2.5"},
+		{name: "complex64", v: complex64(1 + 2i), exp: "(1+2i)"},
+		{name: "complex128", v: 1 + 2i, exp: "(1+2i)"},
+		{name: "array", v: [2]int{1, 2}, exp: "[1 2]"},
+		{name: "chan", v: ch, exp: "<chan int>"},
+		{name: "receive chan", v: rch, exp: "<<-chan string>"},
+		{name: "func", v: func() {}, exp: "<func()>"},
+		{name: "func with signature", v: func(int) error { return nil }, exp: "<func(int) error>"},
+		{name: "interface", v: errors.New("boom"), exp: "boom"},
+		{name: "map", v: map[string]int{"a": 1}, exp: "map[a:1]"},
+		{name: "ptr to struct", v: &encodeTestStruct{A: 1, B: "b"}, exp: "&{1 b}"},
+		{name: "slice", v: []string{"a", "b"}, exp: "[a b]"},
+		{name: "string", v: "hello", exp: "hello"},
+		{name: "struct", v: encodeTestStruct{A: 1, B: "b"}, exp: "{1 b}"},
+		{name: "stringer", v: encodeTestStringer{}, exp: "stringer"},
+		{name: "unsafe pointer", v: unsafe.Pointer(&i), exp: "<unsafe.Pointer>"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := formatValue(test.v); got != test.exp {
+				t.Fatalf("expected '%s', got '%s'", test.exp, got)
+			}
+		})
+	}
+}
+
+type valuerTestUser struct {
+	ID       int
+	Password string
+}
+
+func (u valuerTestUser) LogValue() interface{} {
+	return Fields{"id": u.ID, "password": redacted}
+}
+
+type valuerTestLoop struct{}
+
+func (v valuerTestLoop) LogValue() interface{} { return v }
+
+type valuerTestSecret string
+
+func (valuerTestSecret) LogValue() interface{} { return redacted }
+
+func TestValuer(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw))
+
+	l.Infof(Fields{
+		"user":   valuerTestUser{ID: 1, Password: "hunter2"},
+		"token":  valuerTestSecret("s3cr3t"),
+		"loop":   valuerTestLoop{},
+		"nested": Fields{"token": valuerTestSecret("s3cr3t")},
+	}, valuerTestSecret("message"))
+
+	exp := `"fields":{"loop":"{}","nested":{"token":"REDACTED"},"token":"REDACTED",` +
+		`"user":{"id":"1","password":"REDACTED"}},"message":"REDACTED"`
+	if !strings.Contains(string(mw.byt), exp) {
+		t.Fatalf("expected '%s' in '%s'", exp, mw.byt)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw), WithLevel(InfoLevel))
+
+	var calls int
+	f := Fields{
+		"lazy": Lazy(func() interface{} {
+			calls++
+			return Fields{"heap": 1}
+		}),
+		"func": func() interface{} {
+			calls++
+			return errors.New("boom")
+		},
+	}
+
+	l.Debugf(f, "dropped")
+	if calls != 0 {
+		t.Fatalf("expected '%d' call(s) for a dropped event, got '%d'", 0, calls)
+	}
+
+	l.SetErrorCauses(true)
+	l.Infof(f, "logged")
+	if calls != 2 {
+		t.Fatalf("expected '%d' call(s) for a logged event, got '%d'", 2, calls)
+	}
+
+	exp := `"fields":{"func":{"message":"boom"},"lazy":{"heap":"1"}}`
+	if !strings.Contains(string(mw.byt), exp) {
+		t.Fatalf("expected '%s' in '%s'", exp, mw.byt)
+	}
+}
@@ -0,0 +1,138 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slog
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[reflect.Type]reflect.Value{}
+	// ifaceEncoders holds the encoders of interface types,
+	// in the order they were registered.
+	ifaceEncoders []reflect.Type
+)
+
+var stringType = reflect.TypeOf("")
+
+// kindTypes are the types of the values of typed Fields, by fieldKind.
+var kindTypes = [...]reflect.Type{
+	stringKind:   stringType,
+	intKind:      reflect.TypeOf(int64(0)),
+	uintKind:     reflect.TypeOf(uint64(0)),
+	floatKind:    reflect.TypeOf(float64(0)),
+	boolKind:     reflect.TypeOf(false),
+	durationKind: reflect.TypeOf(time.Duration(0)),
+}
+
+// kindsEncodedBy returns the bits of encodedKinds
+// that an encoder for values of type t sets.
+func kindsEncodedBy(t reflect.Type) uint32 {
+	var bits uint32
+
+	for k, kt := range kindTypes {
+		if kt == nil {
+			continue
+		}
+
+		if kt == t || t.Kind() == reflect.Interface && kt.Implements(t) {
+			bits |= 1 << uint(k)
+		}
+	}
+
+	return bits
+}
+
+// RegisterEncoder registers fn to format every field value and message
+// of its parameter's type, so that domain types render consistently
+// across an organization, for example:
+//
+//	slog.RegisterEncoder(func(ip net.IP) string { return ip.String() })
+//
+// fn must be a function with one parameter that returns a string.
+// If its parameter is an interface type, fn formats every value that
+// implements the interface and has no encoder for its own type.
+// Registering an encoder for a type again replaces it.
+//
+// RegisterEncoder panics if fn does not have the right signature.
+// It is not available in TinyGo builds, which use the tinygo build tag
+// and only have the built-in encoders.
+func RegisterEncoder(fn interface{}) {
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+
+	if t.Kind() != reflect.Func ||
+		t.NumIn() != 1 ||
+		t.NumOut() != 1 ||
+		t.Out(0) != stringType ||
+		t.IsVariadic() {
+		panic(fmt.Sprintf("slog: RegisterEncoder requires a func(T) string, got %T", fn))
+	}
+
+	in := t.In(0)
+
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	if _, ok := encoders[in]; !ok && in.Kind() == reflect.Interface {
+		ifaceEncoders = append(ifaceEncoders, in)
+	}
+
+	encoders[in] = v
+	atomic.StoreUint32(&encodedKinds, atomic.LoadUint32(&encodedKinds)|kindsEncodedBy(in))
+}
+
+// encode formats v with the encoder registered for its type, the
+// built-in encoder for its type, or the first encoder registered for
+// an interface it implements, in that order.
+func encode(v interface{}) (string, bool) {
+	t := reflect.TypeOf(v)
+
+	encodersMu.RLock()
+	fn, ok := encoders[t]
+	encodersMu.RUnlock()
+
+	if ok {
+		return call(fn, v), true
+	}
+
+	if s, ok := builtinEncode(v); ok {
+		return s, true
+	}
+
+	encodersMu.RLock()
+	for _, it := range ifaceEncoders {
+		if t.Implements(it) {
+			fn, ok = encoders[it], true
+			break
+		}
+	}
+	encodersMu.RUnlock()
+
+	if !ok {
+		return "", false
+	}
+
+	return call(fn, v), true
+}
+
+func call(fn reflect.Value, v interface{}) string {
+	return fn.Call([]reflect.Value{reflect.ValueOf(v)})[0].String()
+}
+
+// placeholder formats values that have no meaningful text form, which
+// are channels, functions, and unsafe pointers, as their type.
+func placeholder(v interface{}) (string, bool) {
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return fmt.Sprintf("<%T>", v), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,94 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+)
+
+type encodeTestIP [4]byte
+
+type encodeTestRedactor interface{ Redact() string }
+
+type encodeTestSecret string
+
+func (encodeTestSecret) Redact() string { return "***" }
+
+func TestRegisterEncoder(t *testing.T) {
+	t.Parallel()
+
+	RegisterEncoder(func(ip encodeTestIP) string {
+		return fmt.Sprintf("%d.%d.%d.%d", ip[0], ip[1], ip[2], ip[3])
+	})
+	RegisterEncoder(func(r encodeTestRedactor) string { return r.Redact() })
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw))
+	l.Infof(
+		Fields{"ip": encodeTestIP{10, 0, 0, 1}, "password": encodeTestSecret("hunter2")},
+		encodeTestSecret("message"),
+	)
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Fields["ip"] != "10.0.0.1" {
+		t.Fatalf("expected field '%s', got '%s'", "10.0.0.1", e.Fields["ip"])
+	}
+
+	if e.Fields["password"] != "***" {
+		t.Fatalf("expected field '%s', got '%s'", "***", e.Fields["password"])
+	}
+
+	if e.Message != "***" {
+		t.Fatalf("expected message '%s', got '%s'", "***", e.Message)
+	}
+}
+
+func TestRegisterEncoderSignature(t *testing.T) {
+	t.Parallel()
+
+	for _, fn := range []interface{}{
+		"not a func",
+		func() string { return "" },
+		func(int) int { return 0 },
+		func(int, int) string { return "" },
+		func(...int) string { return "" },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Fatalf("expected a panic for '%T', got none", fn)
+				}
+			}()
+			RegisterEncoder(fn)
+		}()
+	}
+}
+
+func TestKindsEncodedBy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		t    reflect.Type
+		exp  uint32
+	}{
+		{name: "float64", t: reflect.TypeOf(float64(0)), exp: 1 << floatKind},
+		{name: "interface", t: reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), exp: 1 << durationKind},
+		{name: "other", t: reflect.TypeOf(net.IP(nil))},
+	}
+
+	for _, test := range tests {
+		if got := kindsEncodedBy(test.t); got != test.exp {
+			t.Fatalf("%s: expected bits '%b', got '%b'", test.name, test.exp, got)
+		}
+	}
+}
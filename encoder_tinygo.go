@@ -0,0 +1,16 @@
+//go:build tinygo
+// +build tinygo
+
+package slog
+
+// encode formats v with the built-in encoder for its type. TinyGo
+// builds have no RegisterEncoder, whose registry relies on reflection.
+func encode(v interface{}) (string, bool) {
+	return builtinEncode(v)
+}
+
+// placeholder reports false, because telling channels, functions,
+// and unsafe pointers apart requires reflection.
+func placeholder(v interface{}) (string, bool) {
+	return "", false
+}
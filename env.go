@@ -0,0 +1,76 @@
+package slog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// NewFromEnv returns a Logger configured from environment variables,
+// so that a deployment can be reconfigured without code changes:
+//
+//   - SLOG_LEVEL is the minimum level, as accepted by ParseLevel.
+//     It defaults to "trace".
+//   - SLOG_OUTPUT is the name of a registered sink, such as "stdout" or
+//     "stderr", or else the path of a file to append to.
+//     It defaults to "stdout".
+//   - SLOG_FORMAT is the encoding of events. Only "json" is supported,
+//     which is the default.
+//   - SLOG_DEVELOPMENT enables development mode when it is a true value,
+//     as accepted by strconv.ParseBool. It defaults to false.
+//
+// Unset or empty variables take their defaults. An error is returned
+// if a variable has an invalid value or the output cannot be opened.
+func NewFromEnv() (*Logger, error) {
+	return newFromEnv(os.Getenv)
+}
+
+func newFromEnv(getenv func(string) string) (*Logger, error) {
+	lv := TraceLevel
+	if s := getenv("SLOG_LEVEL"); s != "" {
+		var err error
+		if lv, err = ParseLevel(s); err != nil {
+			return nil, fmt.Errorf("slog: SLOG_LEVEL: %w", err)
+		}
+	}
+
+	if s := getenv("SLOG_FORMAT"); s != "" && !strings.EqualFold(s, "json") {
+		return nil, fmt.Errorf("slog: SLOG_FORMAT: unsupported format %q", s)
+	}
+
+	dev := false
+	if s := getenv("SLOG_DEVELOPMENT"); s != "" {
+		var err error
+		if dev, err = strconv.ParseBool(s); err != nil {
+			return nil, fmt.Errorf("slog: SLOG_DEVELOPMENT: %w", err)
+		}
+	}
+
+	out, err := envOutput(getenv("SLOG_OUTPUT"))
+	if err != nil {
+		return nil, fmt.Errorf("slog: SLOG_OUTPUT: %w", err)
+	}
+
+	l := NewLogger(WithOutput(out), WithLevel(lv))
+	l.SetDevelopment(dev)
+
+	return l, nil
+}
+
+func envOutput(s string) (io.Writer, error) {
+	if s == "" {
+		s = "stdout"
+	}
+
+	registryMu.RLock()
+	_, ok := sinks[s]
+	registryMu.RUnlock()
+
+	if ok {
+		return NewSink(s, nil)
+	}
+
+	return NewSink("file", map[string]string{"path": s})
+}
@@ -0,0 +1,93 @@
+package slog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "out.log")
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		lv    Level
+		out   string
+		dev   bool
+		isErr bool
+	}{
+		{name: "defaults", env: nil, lv: TraceLevel, out: "stdout"},
+		{
+			name: "all set",
+			env: map[string]string{
+				"SLOG_LEVEL":       "WARN",
+				"SLOG_OUTPUT":      "stderr",
+				"SLOG_FORMAT":      "json",
+				"SLOG_DEVELOPMENT": "true",
+			},
+			lv:  WarnLevel,
+			out: "stderr",
+			dev: true,
+		},
+		{
+			name: "file output",
+			env:  map[string]string{"SLOG_OUTPUT": path},
+			lv:   TraceLevel,
+			out:  path,
+		},
+		{name: "bad level", env: map[string]string{"SLOG_LEVEL": "loud"}, isErr: true},
+		{name: "bad format", env: map[string]string{"SLOG_FORMAT": "logfmt"}, isErr: true},
+		{name: "bad development", env: map[string]string{"SLOG_DEVELOPMENT": "maybe"}, isErr: true},
+		{name: "bad output", env: map[string]string{"SLOG_OUTPUT": filepath.Join(path, "x", "y")}, isErr: true},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			l, err := newFromEnv(func(k string) string { return test.env[k] })
+			if test.isErr {
+				if err == nil {
+					t.Fatal("expected an error, but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if l.cfg.minLevel != test.lv {
+				t.Fatalf("expected level '%s', got '%s'", test.lv, l.cfg.minLevel)
+			}
+
+			if dev := l.cfg.dupes != nil; dev != test.dev {
+				t.Fatalf("expected development '%t', got '%t'", test.dev, dev)
+			}
+
+			f, ok := l.logger.Writer().(*os.File)
+			if !ok {
+				t.Fatalf("expected an *os.File output, got '%T'", l.logger.Writer())
+			}
+
+			switch test.out {
+			case "stdout":
+				ok = f == os.Stdout
+			case "stderr":
+				ok = f == os.Stderr
+			default:
+				defer f.Close()
+				ok = f.Name() == test.out
+			}
+
+			if !ok {
+				t.Fatalf("expected output '%s', got '%s'", test.out, f.Name())
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package slog
+
+import "errors"
+
+const (
+	// maxStackFrames bounds the number of frames in error stack traces.
+	maxStackFrames = 32
+
+	// maxCauses bounds the number of causes logged for an error.
+	maxCauses = 32
+)
+
+// SetErrorStacks makes the Logger add a "stack" to the fields whose
+// values are errors, which are logged as groups with their "message".
+// The stack is that of the call that logged the event, from the caller
+// outwards, with one "function file:line" string per frame.
+//
+// Stacks are off by default. They are not supported by TinyGo builds.
+func (l *Logger) SetErrorStacks(enabled bool) {
+	l.mu.Lock()
+	old := l.cfg.errorStacks
+	l.cfg.errorStacks = enabled
+	l.mu.Unlock()
+
+	l.configChanged("error_stacks", old, enabled)
+}
+
+// SetErrorCauses makes the Logger add the "causes" of the fields whose
+// values are errors, which are logged as groups with their "message".
+// The causes are the messages of the errors that the error wraps,
+// found with errors.Unwrap, outermost first, so that the root cause
+// of a chain made with fmt.Errorf and %w is visible. Errors that
+// wrap several errors contribute each of them, depth first.
+//
+// Causes are off by default.
+func (l *Logger) SetErrorCauses(enabled bool) {
+	l.mu.Lock()
+	old := l.cfg.errorCauses
+	l.cfg.errorCauses = enabled
+	l.mu.Unlock()
+
+	l.configChanged("error_causes", old, enabled)
+}
+
+// addErrorDetails adds stack, if it is not nil, and the causes,
+// if causes is true, to the groups in formatted of the fields in f
+// whose values are errors.
+func addErrorDetails(formatted, f Fields, stack []string, causes bool) {
+	for k, v := range f {
+		err, ok := resolve(v).(error)
+		if !ok {
+			continue
+		}
+
+		g, ok := formatted[k].(Fields)
+		if !ok {
+			continue
+		}
+
+		if stack != nil {
+			g["stack"] = stack
+		}
+
+		if causes {
+			if c := errorCauses(err); len(c) > 0 {
+				g["causes"] = c
+			}
+		}
+	}
+}
+
+// errorCauses returns the messages of the errors that err wraps.
+func errorCauses(err error) []string {
+	var causes []string
+
+	var walk func(err error)
+	walk = func(err error) {
+		for len(causes) < maxCauses {
+			if m, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, e := range m.Unwrap() {
+					if e != nil && len(causes) < maxCauses {
+						causes = append(causes, e.Error())
+						walk(e)
+					}
+				}
+				return
+			}
+
+			if err = errors.Unwrap(err); err == nil {
+				return
+			}
+			causes = append(causes, err.Error())
+		}
+	}
+	walk(err)
+
+	return causes
+}
+
+// hasError reports whether a value of f is an error.
+func hasError(f Fields) bool {
+	for _, v := range f {
+		if _, ok := resolve(v).(error); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,160 @@
+package slog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrorStacks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		stacks bool
+	}{
+		{name: "off", stacks: false},
+		{name: "on", stacks: true},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			l := NewLogger(WithOutput(mw))
+			l.SetErrorStacks(test.stacks)
+
+			l.Errors("failed", Err(errors.New("boom")), String("other", "value"))
+
+			var e struct {
+				Fields struct {
+					Error struct {
+						Message string   `json:"message"`
+						Stack   []string `json:"stack"`
+					} `json:"error"`
+					Other string `json:"other"`
+				} `json:"fields"`
+			}
+			if err := json.Unmarshal(mw.byt, &e); err != nil {
+				t.Fatal(err)
+			}
+
+			if e.Fields.Error.Message != "boom" || e.Fields.Other != "value" {
+				t.Fatalf("expected the error message and other field, got '%s'", mw.byt)
+			}
+
+			if !test.stacks {
+				if e.Fields.Error.Stack != nil {
+					t.Fatalf("expected no stack, got '%v'", e.Fields.Error.Stack)
+				}
+				return
+			}
+
+			if len(e.Fields.Error.Stack) == 0 {
+				t.Fatal("expected a stack, but got none")
+			}
+
+			if top := e.Fields.Error.Stack[0]; !strings.Contains(top, "TestErrorStacks") ||
+				!strings.Contains(top, "error_test.go:") {
+				t.Fatalf("expected the stack to start at the caller, got '%s'", top)
+			}
+		})
+	}
+}
+
+func errorStacksTestHelper(l *Logger) {
+	l.LogDepth(1, ErrorLevel, "failed", Err(errors.New("boom")))
+}
+
+func TestErrorStacksLogDepth(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw))
+	l.SetErrorStacks(true)
+
+	errorStacksTestHelper(l)
+
+	var e struct {
+		Fields struct {
+			Error struct {
+				Stack []string `json:"stack"`
+			} `json:"error"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(e.Fields.Error.Stack) == 0 {
+		t.Fatal("expected a stack, but got none")
+	}
+
+	if top := e.Fields.Error.Stack[0]; !strings.Contains(top, "TestErrorStacksLogDepth") {
+		t.Fatalf("expected the stack to start at the caller of the helper, got '%s'", top)
+	}
+}
+
+type multiError []error
+
+func (m multiError) Error() string   { return "multiple errors" }
+func (m multiError) Unwrap() []error { return m }
+
+func TestErrorCauses(t *testing.T) {
+	t.Parallel()
+
+	root := errors.New("connection refused")
+
+	tests := []struct {
+		name string
+		err  error
+		exp  []string
+	}{
+		{name: "unwrapped", err: root, exp: nil},
+		{
+			name: "chain",
+			err:  fmt.Errorf("loading user: %w", fmt.Errorf("querying db: %w", root)),
+			exp:  []string{"querying db: connection refused", "connection refused"},
+		},
+		{
+			name: "multiple",
+			err:  multiError{fmt.Errorf("a: %w", root), errors.New("b")},
+			exp:  []string{"a: connection refused", "connection refused", "b"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			l := NewLogger(WithOutput(mw))
+			l.SetErrorCauses(true)
+
+			l.Errors("failed", Err(test.err))
+
+			var e struct {
+				Fields struct {
+					Error struct {
+						Causes []string `json:"causes"`
+					} `json:"error"`
+				} `json:"fields"`
+			}
+			if err := json.Unmarshal(mw.byt, &e); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(e.Fields.Error.Causes, test.exp) {
+				t.Fatalf("expected causes '%v', got '%v'", test.exp, e.Fields.Error.Causes)
+			}
+		})
+	}
+}
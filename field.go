@@ -0,0 +1,267 @@
+package slog
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Field is a key-value pair for logs, made with a typed constructor
+// such as String or Int, for the methods that take fields as
+// arguments, such as Infos:
+//
+//	l.Infos("request", slog.String("method", "GET"), slog.Int("status", 200))
+//
+// Fields store strings, numbers, booleans, and durations without boxing
+// them in an interface{}, and are formatted with strconv rather than
+// with reflection, unless an encoder registered with RegisterEncoder
+// applies to their type, as one registered by SetFloatFormat does
+// to floats.
+// They do not allocate less than Fields maps, though: events are still
+// built as Fields, which hooks receive.
+type Field struct {
+	Key string
+
+	kind fieldKind
+	num  int64
+	str  string
+	val  interface{}
+}
+
+type fieldKind uint8
+
+const (
+	anyKind fieldKind = iota
+	stringKind
+	intKind
+	uintKind
+	floatKind
+	boolKind
+	durationKind
+)
+
+// String returns a Field with a string value.
+func String(k string, v string) Field {
+	return Field{Key: k, kind: stringKind, str: v}
+}
+
+// Int returns a Field with an int value.
+func Int(k string, v int) Field {
+	return Field{Key: k, kind: intKind, num: int64(v)}
+}
+
+// Int64 returns a Field with an int64 value.
+func Int64(k string, v int64) Field {
+	return Field{Key: k, kind: intKind, num: v}
+}
+
+// Uint64 returns a Field with a uint64 value.
+func Uint64(k string, v uint64) Field {
+	return Field{Key: k, kind: uintKind, num: int64(v)}
+}
+
+// Float64 returns a Field with a float64 value.
+func Float64(k string, v float64) Field {
+	return Field{Key: k, kind: floatKind, num: int64(math.Float64bits(v))}
+}
+
+// Bool returns a Field with a bool value.
+func Bool(k string, v bool) Field {
+	f := Field{Key: k, kind: boolKind}
+	if v {
+		f.num = 1
+	}
+
+	return f
+}
+
+// Duration returns a Field with a time.Duration value.
+func Duration(k string, v time.Duration) Field {
+	return Field{Key: k, kind: durationKind, num: int64(v)}
+}
+
+// Time returns a Field with a time.Time value.
+func Time(k string, v time.Time) Field {
+	return Field{Key: k, val: v}
+}
+
+// Err returns a Field named "error" with err as its value,
+// which is logged as a group with its "message".
+func Err(err error) Field {
+	return Field{Key: "error", val: err}
+}
+
+// Any returns a Field with a value of any type.
+func Any(k string, v interface{}) Field {
+	return Field{Key: k, val: v}
+}
+
+// Value returns the value of f.
+func (f Field) Value() interface{} {
+	switch f.kind {
+	case stringKind:
+		return f.str
+	case intKind:
+		return f.num
+	case uintKind:
+		return uint64(f.num)
+	case floatKind:
+		return math.Float64frombits(uint64(f.num))
+	case boolKind:
+		return f.num == 1
+	case durationKind:
+		return time.Duration(f.num)
+	default:
+		return f.val
+	}
+}
+
+// format formats the value of f as formatField does, and returns it
+// resolved if it is an error, so that errors can be classified and
+// detailed without resolving their Valuers again.
+func (f Field) format() (interface{}, error) {
+	if s, ok := f.formatTyped(); ok {
+		return s, nil
+	}
+
+	v := resolve(f.Value())
+	err, _ := v.(error)
+
+	return formatField(v), err
+}
+
+// formatTyped formats the value of f without boxing it, and reports
+// whether it could: values of any type, and of types that have an
+// encoder, are formatted by format instead.
+func (f Field) formatTyped() (string, bool) {
+	if f.kind == anyKind || atomic.LoadUint32(&encodedKinds)&(1<<uint(f.kind)) != 0 {
+		return "", false
+	}
+
+	switch f.kind {
+	case stringKind:
+		return f.str, true
+	case intKind:
+		return strconv.FormatInt(f.num, 10), true
+	case uintKind:
+		return strconv.FormatUint(uint64(f.num), 10), true
+	case floatKind:
+		return strconv.FormatFloat(math.Float64frombits(uint64(f.num)), 'g', -1, 64), true
+	case boolKind:
+		return strconv.FormatBool(f.num == 1), true
+	case durationKind:
+		return time.Duration(f.num).String(), true
+	}
+
+	return "", false
+}
+
+// fieldsOf returns fields as Fields. Later fields replace
+// earlier fields with the same key.
+func fieldsOf(fields []Field) Fields {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	f := make(Fields, len(fields))
+	for _, field := range fields {
+		f[field.Key] = field.Value()
+	}
+
+	return f
+}
+
+// Traces calls the default Logger's Traces method.
+func Traces(msg interface{}, fields ...Field) {
+	defaultLogger.Traces(msg, fields...)
+}
+
+// Debugs calls the default Logger's Debugs method.
+func Debugs(msg interface{}, fields ...Field) {
+	defaultLogger.Debugs(msg, fields...)
+}
+
+// Infos calls the default Logger's Infos method.
+func Infos(msg interface{}, fields ...Field) {
+	defaultLogger.Infos(msg, fields...)
+}
+
+// Warns calls the default Logger's Warns method.
+func Warns(msg interface{}, fields ...Field) {
+	defaultLogger.Warns(msg, fields...)
+}
+
+// Errors calls the default Logger's Errors method.
+func Errors(msg interface{}, fields ...Field) {
+	defaultLogger.Errors(msg, fields...)
+}
+
+// Panics calls the default Logger's Panics method.
+func Panics(msg interface{}, fields ...Field) {
+	defaultLogger.Panics(msg, fields...)
+}
+
+// Fatals calls the default Logger's Fatals method.
+func Fatals(msg interface{}, fields ...Field) {
+	defaultLogger.Fatals(msg, fields...)
+}
+
+// Traces logs a message and fields at the trace level.
+func (l *Logger) Traces(msg interface{}, fields ...Field) {
+	l.logs(0, TraceLevel, fields, msg)
+}
+
+// Debugs logs a message and fields at the debug level.
+func (l *Logger) Debugs(msg interface{}, fields ...Field) {
+	l.logs(0, DebugLevel, fields, msg)
+}
+
+// Infos logs a message and fields at the info level.
+func (l *Logger) Infos(msg interface{}, fields ...Field) {
+	l.logs(0, InfoLevel, fields, msg)
+}
+
+// Warns logs a message and fields at the warn level.
+func (l *Logger) Warns(msg interface{}, fields ...Field) {
+	l.logs(0, WarnLevel, fields, msg)
+}
+
+// Errors logs a message and fields at the error level.
+func (l *Logger) Errors(msg interface{}, fields ...Field) {
+	l.logs(0, ErrorLevel, fields, msg)
+}
+
+// Panics logs a message and fields at the panic level and then panics
+// with a *PanicError that holds the message.
+func (l *Logger) Panics(msg interface{}, fields ...Field) {
+	l.logs(0, PanicLevel, fields, msg)
+}
+
+// Fatals logs a message and fields at the fatal level
+// followed by os.Exit(1).
+func (l *Logger) Fatals(msg interface{}, fields ...Field) {
+	l.logs(0, FatalLevel, fields, msg)
+	os.Exit(1)
+}
+
+// LogDepth calls the default Logger's LogDepth method.
+func LogDepth(depth int, lv Level, msg interface{}, fields ...Field) {
+	defaultLogger.LogDepth(depth, lv, msg, fields...)
+}
+
+// LogDepth logs a message and fields at lv, like Log, skipping depth
+// more stack frames to find the caller, so that helpers that log on
+// behalf of their callers, such as the functions generated by
+// cmd/sloggen, log their callers' file and line number without
+// deriving a Logger with WithCallerSkip on every call.
+//
+// Like Log, LogDepth panics at PanicLevel and exits at FatalLevel.
+func (l *Logger) LogDepth(depth int, lv Level, msg interface{}, fields ...Field) {
+	l.logs(depth, lv, fields, msg)
+
+	if lv == FatalLevel {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,165 @@
+package slog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw))
+
+	l.Infos(
+		"hello",
+		String("string", "v"),
+		Int("int", -1),
+		Int64("int64", 1<<40),
+		Uint64("uint64", 1<<63),
+		Float64("float64", 1.5),
+		Bool("bool", true),
+		Duration("duration", 1500*time.Millisecond),
+		Time("time", time.Date(2021, 6, 9, 15, 41, 20, 0, time.UTC)),
+		Err(errors.New("boom")),
+		Any("any", []int{1, 2}),
+		String("string", "replaced"),
+	)
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	expF := Fields{
+		"string":   "replaced",
+		"int":      "-1",
+		"int64":    "1099511627776",
+		"uint64":   "9223372036854775808",
+		"float64":  "1.5",
+		"bool":     "true",
+		"duration": "1.5s",
+		"time":     "2021-06-09 15:41:20 +0000 UTC",
+		"error":    map[string]interface{}{"message": "boom"},
+		"any":      "[1 2]",
+	}
+
+	if len(expF) != len(e.Fields) {
+		t.Fatalf("expected '%d' field(s), got '%d': %v", len(expF), len(e.Fields), e.Fields)
+	}
+
+	for k, v := range expF {
+		if !reflect.DeepEqual(e.Fields[k], v) {
+			t.Fatalf("expected field '%s' to be '%v', got '%v'", k, v, e.Fields[k])
+		}
+	}
+
+	if !strings.HasPrefix(e.Metadata["file"].(string), "field_test.go:") {
+		t.Fatalf("expected file '%s', got '%s'", "field_test.go", e.Metadata["file"])
+	}
+
+	mw.byt = nil
+	l.Warns("no fields")
+
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Metadata["level"] != string(WarnLevel) {
+		t.Fatalf("expected level '%s', got '%s'", WarnLevel, e.Metadata["level"])
+	}
+}
+
+func TestFieldValue(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("boom")
+
+	tests := []struct {
+		f   Field
+		exp interface{}
+	}{
+		{f: String("k", "v"), exp: "v"},
+		{f: Int("k", -1), exp: int64(-1)},
+		{f: Int64("k", 1<<40), exp: int64(1 << 40)},
+		{f: Uint64("k", 1<<63), exp: uint64(1 << 63)},
+		{f: Float64("k", -1.5), exp: -1.5},
+		{f: Bool("k", true), exp: true},
+		{f: Bool("k", false), exp: false},
+		{f: Duration("k", time.Second), exp: time.Second},
+		{f: Err(err), exp: err},
+		{f: Any("k", nil), exp: nil},
+	}
+
+	for _, test := range tests {
+		if v := test.f.Value(); v != test.exp {
+			t.Fatalf("expected value '%v' (%T), got '%v' (%T)", test.exp, test.exp, v, v)
+		}
+	}
+}
+
+func logDepthTestHelper(l *Logger, msg string) {
+	l.LogDepth(1, InfoLevel, msg, String("a", "b"))
+}
+
+func TestLogDepth(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw))
+
+	_, _, line, _ := runtime.Caller(0)
+	logDepthTestHelper(l, "hello")
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := fmt.Sprintf("field_test.go:%d", line+1); e.Metadata["file"] != exp {
+		t.Fatalf("expected file '%s', got '%s'", exp, e.Metadata["file"])
+	}
+
+	if e.Fields["a"] != "b" || e.Metadata["level"] != string(InfoLevel) {
+		t.Fatalf("expected an info event with field 'a', got '%s'", mw.byt)
+	}
+}
+
+func TestFieldFormatTyped(t *testing.T) {
+	t.Parallel()
+
+	// None of the built-in encoders applies to the types of typed
+	// Fields. Floats are left out, because TestSetFloatFormat sets
+	// a float format.
+	tests := []struct {
+		f   Field
+		exp string
+	}{
+		{f: String("k", "v"), exp: "v"},
+		{f: Int("k", -3), exp: "-3"},
+		{f: Uint64("k", 1<<63), exp: "9223372036854775808"},
+		{f: Bool("k", true), exp: "true"},
+		{f: Duration("k", time.Second), exp: "1s"},
+	}
+
+	for _, test := range tests {
+		s, ok := test.f.formatTyped()
+		if !ok || s != test.exp {
+			t.Fatalf("expected '%s' formatted without boxing, got '%s' and '%v'", test.exp, s, ok)
+		}
+
+		if v := formatField(test.f.Value()); v != test.exp {
+			t.Fatalf("expected '%s' like Fields maps, got '%v'", test.exp, v)
+		}
+	}
+
+	if _, ok := Any("k", 1).formatTyped(); ok {
+		t.Fatal("expected Any to be formatted with boxing, but it was not")
+	}
+}
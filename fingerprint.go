@@ -0,0 +1,68 @@
+package slog
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// volatileRe matches the parts of a message that commonly vary between
+// otherwise identical events, such as numbers, hex strings, and UUIDs.
+var volatileRe = regexp.MustCompile(`[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}|0x[0-9a-fA-F]+|[0-9a-fA-F]*[0-9][0-9a-fA-F]*`)
+
+// SetFingerprint makes the Logger stamp every event with a "fingerprint"
+// in its metadata, so that log aggregators can group events that are
+// the same apart from their parameters.
+//
+// The fingerprint is computed from the message with its volatile values,
+// such as numbers and IDs, removed, and from the values of the fields
+// named by keys. Fields not named by keys do not affect the fingerprint.
+//
+// If enabled is false, events are not stamped, which is the default.
+func (l *Logger) SetFingerprint(enabled bool, keys ...string) {
+	l.mu.Lock()
+	old := describeKeys(l.cfg.fingerprintKeys)
+
+	if !enabled {
+		l.cfg.fingerprintKeys = nil
+	} else {
+		l.cfg.fingerprintKeys = append([]string{}, keys...)
+		sort.Strings(l.cfg.fingerprintKeys)
+	}
+
+	new := describeKeys(l.cfg.fingerprintKeys)
+	l.mu.Unlock()
+
+	l.configChanged("fingerprint", old, new)
+}
+
+// describeKeys describes fingerprint keys, or returns "off"
+// if keys is nil.
+func describeKeys(keys []string) string {
+	if keys == nil {
+		return "off"
+	}
+
+	return "[" + strings.Join(keys, " ") + "]"
+}
+
+func fingerprint(msg string, f Fields, keys []string) string {
+	h := fnv.New64a()
+	h.Write([]byte(volatileRe.ReplaceAllString(msg, "?")))
+
+	for _, k := range keys {
+		v, ok := f[k]
+		if !ok {
+			continue
+		}
+		h.Write([]byte{0})
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write([]byte(fmt.Sprint(v)))
+	}
+
+	return strconv.FormatUint(h.Sum64(), 16)
+}
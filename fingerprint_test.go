@@ -0,0 +1,95 @@
+package slog
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		keys  []string
+		a     string
+		af    Fields
+		b     string
+		bf    Fields
+		equal bool
+	}{
+		{
+			name:  "same template",
+			a:     "user 42 failed after 3 attempts",
+			b:     "user 1337 failed after 10 attempts",
+			equal: true,
+		},
+		{
+			name:  "same template with ids",
+			a:     "request 3f2a9c1e-8f3b-4c7d-9a2e-1b2c3d4e5f60 timed out",
+			b:     "request 0a1b2c3d-4e5f-4a7b-8c9d-0e1f2a3b4c5d timed out",
+			equal: true,
+		},
+		{
+			name:  "different template",
+			a:     "user 42 failed",
+			b:     "user 42 succeeded",
+			equal: false,
+		},
+		{
+			name:  "unselected fields ignored",
+			keys:  []string{"kind"},
+			a:     "failed",
+			af:    Fields{"kind": "timeout", "user": "a"},
+			b:     "failed",
+			bf:    Fields{"kind": "timeout", "user": "b"},
+			equal: true,
+		},
+		{
+			name:  "selected fields differ",
+			keys:  []string{"kind"},
+			a:     "failed",
+			af:    Fields{"kind": "timeout"},
+			b:     "failed",
+			bf:    Fields{"kind": "refused"},
+			equal: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			l := NewLogger(WithOutput(mw))
+			l.SetFingerprint(true, test.keys...)
+
+			get := func(msg string, f Fields) string {
+				l.Infof(f, msg)
+
+				var e event
+				if err := json.Unmarshal(mw.byt, &e); err != nil {
+					t.Fatal(err)
+				}
+
+				fp, _ := e.Metadata["fingerprint"].(string)
+				if fp == "" {
+					t.Fatal("expected a fingerprint, got none")
+				}
+
+				return fp
+			}
+
+			a, b := get(test.a, test.af), get(test.b, test.bf)
+			if (a == b) != test.equal {
+				t.Fatalf(
+					"expected equal fingerprints to be '%t', got '%s' and '%s'",
+					test.equal,
+					a,
+					b,
+				)
+			}
+		})
+	}
+}
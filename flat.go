@@ -0,0 +1,35 @@
+package slog
+
+// WithFlat makes the Logger encode the metadata, fields, and message
+// of events at the top level of one JSON object, as most log backends
+// expect, instead of nesting them under "_metadata" and "fields":
+//
+//	{"file":"main.go:8","level":"info","message":"hello","time":"...","user_id":"1"}
+//
+// Keys are encoded in sorted order. Fields whose keys collide with the
+// metadata or the message are prefixed with "fields.", so that they
+// cannot replace them. Groups of fields stay nested.
+func WithFlat() Option {
+	return func(l *Logger) {
+		l.flat = true
+	}
+}
+
+// flatten returns the metadata, fields, and message of e
+// at the top level of one object.
+func flatten(e *event, k *Keys) Fields {
+	f := make(Fields, len(e.Metadata)+len(e.Fields)+1)
+	for mk, v := range e.Metadata {
+		f[mk] = v
+	}
+	f[k.Message] = e.Message
+
+	for fk, v := range e.Fields {
+		if _, ok := f[fk]; ok {
+			fk = "fields." + fk
+		}
+		f[fk] = v
+	}
+
+	return f
+}
@@ -0,0 +1,54 @@
+package slog
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFlat(t *testing.T) {
+	t.Parallel()
+
+	clock := func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name string
+		opts []Option
+		f    Fields
+		exp  string
+	}{
+		{
+			name: "flat",
+			f:    Fields{"user_id": 1, "http": Fields{"status": 200}},
+			exp: `{"event_id":"1","http":{"status":"200"},"level":"info","message":"hello",` +
+				`"time":"2021-01-01T00:00:00Z","user_id":"1"}`,
+		},
+		{
+			name: "collisions",
+			f:    Fields{"level": "high", "message": "other"},
+			exp: `{"event_id":"1","fields.level":"high","fields.message":"other","level":"info",` +
+				`"message":"hello","time":"2021-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "keys",
+			opts: []Option{WithKeys(Keys{Message: "msg", Time: "ts"})},
+			exp:  `{"event_id":"1","level":"info","msg":"hello","ts":"2021-01-01T00:00:00Z"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			opts := append([]Option{WithOutput(mw), WithDeterministic(clock), WithFlat()}, test.opts...)
+			NewLogger(opts...).With(Fields{}).Infof(test.f, "hello")
+
+			if got := strings.TrimSpace(string(mw.byt)); got != test.exp {
+				t.Fatalf("expected '%s', got '%s'", test.exp, got)
+			}
+		})
+	}
+}
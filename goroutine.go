@@ -0,0 +1,37 @@
+package slog
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// WithGoroutineID makes the Logger log the ID of the calling goroutine
+// as "goroutine" in the metadata, for example to tell apart the events
+// of concurrent requests while debugging. It is off by default because
+// the ID is parsed from a stack trace on every event, which is slow.
+func WithGoroutineID() Option {
+	return func(l *Logger) {
+		l.goroutineID = true
+	}
+}
+
+// goroutineID returns the ID of the calling goroutine, or "" if it
+// cannot be found, which is the case on some runtimes.
+func goroutineID() string {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+
+	// The stack trace starts with a line such as "goroutine 7 [running]:".
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	i := bytes.IndexByte(b, ' ')
+	if i < 0 {
+		return ""
+	}
+
+	if _, err := strconv.ParseUint(string(b[:i]), 10, 64); err != nil {
+		return ""
+	}
+
+	return string(b[:i])
+}
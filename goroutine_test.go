@@ -0,0 +1,49 @@
+package slog
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+func TestGoroutineID(t *testing.T) {
+	t.Parallel()
+
+	goroutine := func(opts ...Option) string {
+		mw := &mockWriter{}
+		l := NewLogger(append([]Option{WithOutput(mw)}, opts...)...)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			l.Named("a").Info("hello")
+		}()
+		<-done
+
+		var e struct {
+			Metadata map[string]string `json:"_metadata"`
+		}
+		if err := json.Unmarshal(mw.byt, &e); err != nil {
+			t.Fatal(err)
+		}
+
+		return e.Metadata["goroutine"]
+	}
+
+	if id := goroutine(); id != "" {
+		t.Fatalf("expected no goroutine by default, got '%s'", id)
+	}
+
+	a, b := goroutine(WithGoroutineID()), goroutine(WithGoroutineID())
+	if _, err := strconv.ParseUint(a, 10, 64); err != nil {
+		t.Fatalf("expected a numeric goroutine ID, got '%s'", a)
+	}
+
+	if a == b {
+		t.Fatalf("expected different IDs for different goroutines, got '%s' twice", a)
+	}
+
+	if id := goroutineID(); id == a || id == b {
+		t.Fatalf("expected the test's goroutine ID to differ, got '%s'", id)
+	}
+}
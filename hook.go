@@ -0,0 +1,115 @@
+package slog
+
+import (
+	"sync"
+	"time"
+)
+
+// Hook is called with every Event a Logger logs, before the
+// Event is written. A Hook may modify the Event's Fields.
+//
+// Fire may be called concurrently.
+type Hook interface {
+	Fire(e *Event)
+}
+
+// HookFunc is an adapter to allow the use of ordinary functions as Hooks.
+type HookFunc func(e *Event)
+
+// Fire calls f(e).
+func (f HookFunc) Fire(e *Event) {
+	f(e)
+}
+
+// AddHook adds a Hook that fires for every subsequent Event.
+// Hooks fire in the order they were added.
+func (l *Logger) AddHook(h Hook) {
+	l.mu.Lock()
+	old := len(l.cfg.hooks)
+	l.cfg.hooks = append(l.cfg.hooks, h)
+	l.mu.Unlock()
+
+	l.configChanged("hooks", old, old+1)
+}
+
+// BurnTracker is a Hook that tracks the ratio of error-level
+// events (error and more severe levels) to all events over a sliding
+// window. It is a crude availability signal for services
+// without metrics.
+type BurnTracker struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	buckets []burnBucket
+}
+
+type burnBucket struct {
+	sec    int64
+	total  int
+	errors int
+}
+
+// NewBurnTracker returns a BurnTracker that remembers events for window,
+// rounded up to the nearest second.
+func NewBurnTracker(window time.Duration) *BurnTracker {
+	n := int((window + time.Second - 1) / time.Second)
+	if n < 1 {
+		n = 1
+	}
+
+	return &BurnTracker{
+		now:     time.Now,
+		buckets: make([]burnBucket, n),
+	}
+}
+
+// Fire records e at the current time, rather than at e.Time, so that
+// events are counted in the window that Ratio looks at even if their
+// time comes from another clock.
+func (b *BurnTracker) Fire(e *Event) {
+	isErr := isErrorLevel(e.Level)
+
+	sec := b.now().Unix()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// sec is negative before 1970, so the remainder is normalized
+	// to index the buckets.
+	n := int64(len(b.buckets))
+	bk := &b.buckets[int((sec%n+n)%n)]
+	if bk.sec != sec {
+		*bk = burnBucket{sec: sec}
+	}
+
+	bk.total++
+	if isErr {
+		bk.errors++
+	}
+}
+
+// Ratio returns the ratio of error-level events to all events
+// over the last d, which is capped to the BurnTracker's window.
+// It returns 0 if no events were logged.
+func (b *BurnTracker) Ratio(d time.Duration) float64 {
+	var (
+		now   = b.now().Unix()
+		since = now - int64(d/time.Second)
+	)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var total, errors int
+	for _, bk := range b.buckets {
+		if bk.sec > since && bk.sec <= now {
+			total += bk.total
+			errors += bk.errors
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(errors) / float64(total)
+}
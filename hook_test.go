@@ -0,0 +1,152 @@
+package slog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestHook(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mw    = &mockWriter{}
+		l     = NewLogger(WithOutput(mw))
+		fired []string
+	)
+
+	l.AddHook(HookFunc(func(e *Event) {
+		fired = append(fired, e.Message)
+		e.Fields["hooked"] = "true"
+	}))
+
+	l.Info("hello")
+
+	if len(fired) != 1 || fired[0] != "hello" {
+		t.Fatalf("expected hook to fire for 'hello', got '%v'", fired)
+	}
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Fields["hooked"] != "true" {
+		t.Fatalf("expected field '%s', got '%s'", "true", e.Fields["hooked"])
+	}
+}
+
+func TestBurnTracker(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now = time.Unix(1000, 0)
+		b   = NewBurnTracker(10 * time.Second)
+		l   = NewLogger(WithOutput(&mockWriter{}))
+	)
+
+	b.now = func() time.Time { return now }
+	l.AddHook(b)
+
+	if r := b.Ratio(10 * time.Second); r != 0 {
+		t.Fatalf("expected ratio '%v', got '%v'", 0, r)
+	}
+
+	l.Info("ok")
+	l.Error("failed")
+
+	now = now.Add(5 * time.Second)
+	l.Info("ok")
+	l.Info("ok")
+
+	if r := b.Ratio(10 * time.Second); r != 0.25 {
+		t.Fatalf("expected ratio '%v', got '%v'", 0.25, r)
+	}
+
+	if r := b.Ratio(time.Second); r != 0 {
+		t.Fatalf("expected ratio '%v', got '%v'", 0, r)
+	}
+
+	now = now.Add(6 * time.Second)
+	if r := b.Ratio(10 * time.Second); r != 0 {
+		t.Fatalf("expected ratio '%v' once errors left the window, got '%v'", 0, r)
+	}
+
+	// Events are counted by the clock of the BurnTracker,
+	// not by their own time.
+	b.Fire(&Event{Level: ErrorLevel, Time: time.Unix(0, 0)})
+	if r := b.Ratio(time.Second); r != 1 {
+		t.Fatalf("expected ratio '%v' for an event with an old time, got '%v'", 1, r)
+	}
+}
+
+func TestBurnTrackerBefore1970(t *testing.T) {
+	t.Parallel()
+
+	var (
+		b = NewBurnTracker(7 * time.Second)
+		l = NewLogger(WithOutput(&mockWriter{}), WithClock(func() time.Time { return time.Time{} }))
+	)
+
+	b.now = func() time.Time { return time.Time{} }
+	l.AddHook(b)
+
+	l.Info("ok")
+	l.Error("failed")
+
+	if r := b.Ratio(7 * time.Second); r != 0.5 {
+		t.Fatalf("expected ratio '%v', got '%v'", 0.5, r)
+	}
+}
+
+func TestReemit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		w = &linesWriter{}
+		l = NewLogger(WithOutput(w))
+	)
+
+	l.AddHook(HookFunc(func(e *Event) {
+		if e.Level == WarnLevel && e.Fields["latency_ms"] == "900" {
+			l.Reemit(e, ErrorLevel)
+		}
+	}))
+
+	l.Warnf(Fields{"latency_ms": 900}, "slow request")
+
+	if len(w.lines) != 2 {
+		t.Fatalf("expected '%d' line(s), got '%d'", 2, len(w.lines))
+	}
+
+	var e event
+	if err := json.Unmarshal([]byte(w.lines[0]), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Metadata["level"] != string(ErrorLevel) {
+		t.Fatalf("expected level '%s', got '%s'", ErrorLevel, e.Metadata["level"])
+	}
+
+	if e.Fields["latency_ms"] != "900" || e.Message != "slow request" {
+		t.Fatalf("expected the original fields and message, got '%v'", e)
+	}
+
+	if fe := l.FirstError(); fe == nil || fe.Message != "slow request" {
+		t.Fatalf("expected the reemitted event to be the first error, got '%v'", fe)
+	}
+}
+
+func TestEventClone(t *testing.T) {
+	t.Parallel()
+
+	e := &Event{Fields: Fields{"a": "1"}, Metadata: Fields{"b": "2"}}
+	c := e.Clone()
+
+	c.Fields["a"] = "changed"
+	c.Metadata["b"] = "changed"
+
+	if e.Fields["a"] != "1" || e.Metadata["b"] != "2" {
+		t.Fatalf("expected the original to be unchanged, got '%v'", e)
+	}
+}
@@ -0,0 +1,92 @@
+package slog
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// redacted replaces values that must not be logged.
+const redacted = "REDACTED"
+
+// loggedHeaders are the headers that Request and Response include.
+// Headers that commonly carry credentials, such as Authorization
+// and Cookie, are deliberately absent.
+var loggedHeaders = []string{
+	"Content-Type",
+	"User-Agent",
+	"X-Request-Id",
+}
+
+// Request returns a curated set of fields that describe r: its method,
+// URL, protocol, content length, and selected headers. The URL's user
+// information is removed and its query values are redacted.
+//
+// The fields are grouped under "http", with the headers grouped
+// under "header", so that they can be combined with other fields.
+func Request(r *http.Request) Fields {
+	f := Fields{
+		"method":         r.Method,
+		"proto":          r.Proto,
+		"content_length": r.ContentLength,
+	}
+
+	if r.URL != nil {
+		f["url"] = redactURL(r.URL)
+	}
+
+	addHeaders(f, r.Header)
+
+	return Fields{"http": f}
+}
+
+// Response returns a curated set of fields that describe resp:
+// its status code, content length, and selected headers, as well
+// as the method and URL of its request, if known.
+//
+// The fields are grouped under "http", with the headers grouped
+// under "header", so that they can be combined with other fields.
+func Response(resp *http.Response) Fields {
+	f := Fields{
+		"status":         resp.StatusCode,
+		"content_length": resp.ContentLength,
+	}
+
+	if resp.Request != nil {
+		f["method"] = resp.Request.Method
+		if resp.Request.URL != nil {
+			f["url"] = redactURL(resp.Request.URL)
+		}
+	}
+
+	addHeaders(f, resp.Header)
+
+	return Fields{"http": f}
+}
+
+func addHeaders(f Fields, h http.Header) {
+	headers := Fields{}
+	for _, k := range loggedHeaders {
+		if v := h.Get(k); v != "" {
+			headers[k] = v
+		}
+	}
+
+	if len(headers) > 0 {
+		f["header"] = headers
+	}
+}
+
+func redactURL(u *url.URL) string {
+	c := *u
+	c.User = nil
+
+	if c.RawQuery != "" {
+		q := c.Query()
+		for k := range q {
+			q[k] = []string{redacted}
+		}
+		c.RawQuery = q.Encode()
+	}
+
+	return c.String()
+}
@@ -0,0 +1,215 @@
+package slog
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// IDGenerator generates unique IDs, such as event IDs and request IDs,
+// so that organizations can standardize on one ID format.
+//
+// NewID may be called concurrently.
+type IDGenerator interface {
+	NewID() string
+}
+
+// IDGeneratorFunc is an adapter to allow the use of ordinary functions
+// as IDGenerators.
+type IDGeneratorFunc func() string
+
+// NewID returns f().
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
+
+// UUIDv4 is an IDGenerator of random (version 4) UUIDs.
+var UUIDv4 IDGenerator = IDGeneratorFunc(func() string {
+	var u [16]byte
+	rand.Read(u[:])
+
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+
+	return formatUUID(u)
+})
+
+// UUIDv7 is an IDGenerator of time-ordered (version 7) UUIDs.
+var UUIDv7 IDGenerator = IDGeneratorFunc(func() string {
+	var u [16]byte
+	rand.Read(u[6:])
+
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+
+	u[6] = (u[6] & 0x0f) | 0x70
+	u[8] = (u[8] & 0x3f) | 0x80
+
+	return formatUUID(u)
+})
+
+// ULID is an IDGenerator of ULIDs, 26 character, time-ordered IDs made
+// up of a millisecond timestamp and 80 random bits, encoded in
+// Crockford's base32, such as "01ARZ3NDEKTSV4RRFFQ69G5FAV". IDs made
+// in the same millisecond are not ordered among themselves.
+var ULID IDGenerator = IDGeneratorFunc(func() string {
+	var u [16]byte
+	rand.Read(u[6:])
+
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+
+	return formatULID(u)
+})
+
+// crockford is the alphabet of Crockford's base32.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// formatULID encodes the 128 bits of u as 26 base32 characters,
+// the first of which holds the 3 most significant bits.
+func formatULID(u [16]byte) string {
+	var buf [26]byte
+
+	hi := binary.BigEndian.Uint64(u[:8])
+	lo := binary.BigEndian.Uint64(u[8:])
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(buf[:])
+}
+
+func formatUUID(u [16]byte) string {
+	var buf [36]byte
+
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+
+	return string(buf[:])
+}
+
+var xidEncoding = base32.NewEncoding("0123456789abcdefghijklmnopqrstuv").
+	WithPadding(base32.NoPadding)
+
+// XIDGenerator is an IDGenerator of 20 character, time-ordered xids.
+type XIDGenerator struct {
+	mu      sync.Mutex
+	machine [5]byte
+	counter uint32
+}
+
+// NewXIDGenerator returns an XIDGenerator with a random machine identifier
+// and counter.
+func NewXIDGenerator() *XIDGenerator {
+	var (
+		g   = &XIDGenerator{}
+		ctr [4]byte
+	)
+
+	rand.Read(g.machine[:])
+	rand.Read(ctr[:])
+	g.counter = binary.BigEndian.Uint32(ctr[:])
+
+	return g
+}
+
+// NewID returns a new xid.
+func (g *XIDGenerator) NewID() string {
+	g.mu.Lock()
+	g.counter++
+	ctr := g.counter
+	g.mu.Unlock()
+
+	var id [12]byte
+	binary.BigEndian.PutUint32(id[0:4], uint32(time.Now().Unix()))
+	copy(id[4:9], g.machine[:])
+	id[9] = byte(ctr >> 16)
+	id[10] = byte(ctr >> 8)
+	id[11] = byte(ctr)
+
+	return xidEncoding.EncodeToString(id[:])
+}
+
+// SequenceGenerator is an IDGenerator of sequence numbers, starting at 1,
+// formatted in base 10. Its IDs are only unique among those of one
+// generator, but are the same every time a program runs, which suits
+// deterministic logs. The zero value is ready to use.
+type SequenceGenerator struct {
+	n uint64
+}
+
+// NewID returns the next sequence number.
+func (g *SequenceGenerator) NewID() string {
+	return strconv.FormatUint(atomic.AddUint64(&g.n, 1), 10)
+}
+
+// snowflakeEpoch is the custom epoch of snowflake IDs, in unix milliseconds.
+const snowflakeEpoch = 1288834974657
+
+// SnowflakeGenerator is an IDGenerator of 64 bit snowflake IDs,
+// made up of a millisecond timestamp, a 10 bit node ID,
+// and a 12 bit sequence number, formatted in base 10.
+type SnowflakeGenerator struct {
+	mu   sync.Mutex
+	node int64
+	ms   int64
+	seq  int64
+}
+
+// NewSnowflakeGenerator returns a SnowflakeGenerator for node,
+// which must be between 0 and 1023.
+func NewSnowflakeGenerator(node int64) (*SnowflakeGenerator, error) {
+	if node < 0 || node > 1023 {
+		return nil, errors.New("slog: snowflake node must be between 0 and 1023")
+	}
+
+	return &SnowflakeGenerator{node: node}, nil
+}
+
+// NewID returns a new snowflake ID. If more than 4096 IDs are
+// requested in one millisecond, it waits for the next millisecond.
+func (g *SnowflakeGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := time.Now().UnixNano()/int64(time.Millisecond) - snowflakeEpoch
+	if ms <= g.ms {
+		ms = g.ms
+		g.seq = (g.seq + 1) & 0xfff
+		if g.seq == 0 {
+			for ms <= g.ms {
+				ms = time.Now().UnixNano()/int64(time.Millisecond) - snowflakeEpoch
+			}
+		}
+	} else {
+		g.seq = 0
+	}
+	g.ms = ms
+
+	return strconv.FormatInt(ms<<22|g.node<<12|g.seq, 10)
+}
@@ -0,0 +1,125 @@
+package slog
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestIDGenerators(t *testing.T) {
+	t.Parallel()
+
+	snowflake, err := NewSnowflakeGenerator(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		g    IDGenerator
+		exp  *regexp.Regexp
+	}{
+		{
+			name: "uuidv4",
+			g:    UUIDv4,
+			exp:  regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`),
+		},
+		{
+			name: "uuidv7",
+			g:    UUIDv7,
+			exp:  regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`),
+		},
+		{
+			name: "ulid",
+			g:    ULID,
+			exp:  regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`),
+		},
+		{
+			name: "xid",
+			g:    NewXIDGenerator(),
+			exp:  regexp.MustCompile(`^[0-9a-v]{20}$`),
+		},
+		{
+			name: "snowflake",
+			g:    snowflake,
+			exp:  regexp.MustCompile(`^[0-9]+$`),
+		},
+		{
+			name: "sequence",
+			g:    &SequenceGenerator{},
+			exp:  regexp.MustCompile(`^[1-9][0-9]*$`),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			seen := make(map[string]bool)
+			for i := 0; i < 10000; i++ {
+				id := test.g.NewID()
+				if !test.exp.MatchString(id) {
+					t.Fatalf("expected id to match '%s', got '%s'", test.exp, id)
+				}
+
+				if seen[id] {
+					t.Fatalf("expected unique ids, got '%s' twice", id)
+				}
+				seen[id] = true
+			}
+		})
+	}
+}
+
+func TestSnowflakeNode(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewSnowflakeGenerator(1024); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	g, err := NewSnowflakeGenerator(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := strconv.ParseInt(g.NewID(), 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if node := (id >> 12) & 0x3ff; node != 5 {
+		t.Fatalf("expected node '%d', got '%d'", 5, node)
+	}
+}
+
+func TestFormatULID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		u   [16]byte
+		exp string
+	}{
+		{exp: "00000000000000000000000000"},
+		{
+			u:   [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			exp: "7ZZZZZZZZZZZZZZZZZZZZZZZZZ",
+		},
+		{
+			u:   [16]byte{0x01, 0x56, 0x3d, 0xf3, 0x64, 0x81},
+			exp: "01ARYZ6S410000000000000000",
+		},
+		{
+			u:   [16]byte{15: 0x21},
+			exp: "00000000000000000000000011",
+		},
+	}
+
+	for _, test := range tests {
+		if got := formatULID(test.u); got != test.exp {
+			t.Fatalf("expected '%s', got '%s'", test.exp, got)
+		}
+	}
+}
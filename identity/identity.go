@@ -0,0 +1,397 @@
+// Package identity adds the identity of the machine that a program runs
+// on, such as its hostname, instance ID, and zone, to events. Identities
+// come from a Provider, with built-ins for the hostname and for the
+// metadata services of EC2, GCE, and Azure.
+//
+// Importing the package registers the "identity" hook, so that it can
+// be configured by name, for example with the config package:
+//
+//	{"hooks": [{"name": "identity", "params": {"provider": "ec2", "ttl": "10m"}}]}
+//
+// The "provider" parameter is one of "host", the default, "ec2", "gce",
+// or "azure", and "ttl" is how long identities are cached,
+// 5 minutes by default.
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/safe-waters/slog"
+)
+
+// DefaultTTL is how long the "identity" hook caches identities
+// by default.
+const DefaultTTL = 5 * time.Minute
+
+// timeout bounds requests to metadata services, which are local
+// and answer quickly when they exist at all.
+const timeout = 2 * time.Second
+
+// Identity identifies a machine. Empty values are unknown.
+type Identity struct {
+	Hostname   string
+	InstanceID string
+	Zone       string
+}
+
+// Fields returns the known values of id as the fields "hostname",
+// "instance_id", and "zone".
+func (id Identity) Fields() slog.Fields {
+	f := slog.Fields{}
+	for k, v := range map[string]string{
+		"hostname":    id.Hostname,
+		"instance_id": id.InstanceID,
+		"zone":        id.Zone,
+	} {
+		if v != "" {
+			f[k] = v
+		}
+	}
+
+	return f
+}
+
+// Provider provides the Identity of the machine.
+//
+// Identity may be called concurrently.
+type Provider interface {
+	Identity(ctx context.Context) (Identity, error)
+}
+
+// ProviderFunc is an adapter to allow the use of ordinary functions
+// as Providers.
+type ProviderFunc func(ctx context.Context) (Identity, error)
+
+// Identity calls f(ctx).
+func (f ProviderFunc) Identity(ctx context.Context) (Identity, error) {
+	return f(ctx)
+}
+
+// Host provides the hostname reported by the operating system.
+var Host Provider = ProviderFunc(func(context.Context) (Identity, error) {
+	h, err := os.Hostname()
+	if err != nil {
+		return Identity{}, fmt.Errorf("identity: %w", err)
+	}
+
+	return Identity{Hostname: h}, nil
+})
+
+// EC2 provides the identity of an Amazon EC2 instance from the
+// instance metadata service, using IMDSv2.
+type EC2 struct {
+	// Endpoint defaults to "http://169.254.169.254".
+	Endpoint string
+	// Client defaults to a client that times out after 2 seconds.
+	Client *http.Client
+}
+
+// Identity implements Provider.
+func (p *EC2) Identity(ctx context.Context) (Identity, error) {
+	base := endpoint(p.Endpoint, "http://169.254.169.254")
+
+	token, err := fetch(ctx, p.Client, http.MethodPut, base+"/latest/api/token",
+		map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": "21600"})
+	if err != nil {
+		return Identity{}, err
+	}
+
+	var (
+		id     Identity
+		header = map[string]string{"X-aws-ec2-metadata-token": token}
+	)
+
+	for path, dst := range map[string]*string{
+		"/latest/meta-data/local-hostname":              &id.Hostname,
+		"/latest/meta-data/instance-id":                 &id.InstanceID,
+		"/latest/meta-data/placement/availability-zone": &id.Zone,
+	} {
+		if *dst, err = fetch(ctx, p.Client, http.MethodGet, base+path, header); err != nil {
+			return Identity{}, err
+		}
+	}
+
+	return id, nil
+}
+
+// GCE provides the identity of a Google Compute Engine instance
+// from the metadata server.
+type GCE struct {
+	// Endpoint defaults to "http://metadata.google.internal".
+	Endpoint string
+	// Client defaults to a client that times out after 2 seconds.
+	Client *http.Client
+}
+
+// Identity implements Provider.
+func (p *GCE) Identity(ctx context.Context) (Identity, error) {
+	var (
+		base   = endpoint(p.Endpoint, "http://metadata.google.internal")
+		header = map[string]string{"Metadata-Flavor": "Google"}
+		id     Identity
+		err    error
+	)
+
+	for path, dst := range map[string]*string{
+		"/computeMetadata/v1/instance/hostname": &id.Hostname,
+		"/computeMetadata/v1/instance/id":       &id.InstanceID,
+		"/computeMetadata/v1/instance/zone":     &id.Zone,
+	} {
+		if *dst, err = fetch(ctx, p.Client, http.MethodGet, base+path, header); err != nil {
+			return Identity{}, err
+		}
+	}
+
+	// Zones are returned as "projects/<number>/zones/<zone>".
+	id.Zone = id.Zone[strings.LastIndex(id.Zone, "/")+1:]
+
+	return id, nil
+}
+
+// Azure provides the identity of an Azure virtual machine from the
+// instance metadata service. The zone is the location of the
+// machine, followed by its availability zone, if it has one,
+// such as "westeurope-1".
+type Azure struct {
+	// Endpoint defaults to "http://169.254.169.254".
+	Endpoint string
+	// Client defaults to a client that times out after 2 seconds.
+	Client *http.Client
+}
+
+// Identity implements Provider.
+func (p *Azure) Identity(ctx context.Context) (Identity, error) {
+	base := endpoint(p.Endpoint, "http://169.254.169.254")
+
+	body, err := fetch(ctx, p.Client, http.MethodGet,
+		base+"/metadata/instance/compute?api-version=2021-02-01&format=json",
+		map[string]string{"Metadata": "true"})
+	if err != nil {
+		return Identity{}, err
+	}
+
+	var c struct {
+		Name     string `json:"name"`
+		VMID     string `json:"vmId"`
+		Location string `json:"location"`
+		Zone     string `json:"zone"`
+	}
+	if err := json.Unmarshal([]byte(body), &c); err != nil {
+		return Identity{}, fmt.Errorf("identity: %w", err)
+	}
+
+	id := Identity{Hostname: c.Name, InstanceID: c.VMID, Zone: c.Location}
+	if c.Zone != "" {
+		id.Zone += "-" + c.Zone
+	}
+
+	return id, nil
+}
+
+func endpoint(e, def string) string {
+	if e == "" {
+		return def
+	}
+
+	return strings.TrimSuffix(e, "/")
+}
+
+// fetch makes a request to a metadata service
+// and returns the body of its response.
+func fetch(ctx context.Context, c *http.Client, method, url string, header map[string]string) (string, error) {
+	if c == nil {
+		c = &http.Client{Timeout: timeout}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("identity: %w", err)
+	}
+
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("identity: %w", err)
+	}
+	defer resp.Body.Close()
+
+	byt, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("identity: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("identity: %s %s: %s", method, url, resp.Status)
+	}
+
+	return strings.TrimSpace(string(byt)), nil
+}
+
+// minBackoff is how long Cache waits before fetching an identity
+// again after the first failure. The wait doubles with every
+// failure, up to the TTL of the cache.
+const minBackoff = time.Second
+
+// Cache returns a Provider that caches the identity provided by p
+// for ttl. Identities are fetched in the background, with a timeout
+// of 2 seconds, so that only callers that come before the first fetch
+// completes wait for it, as long as their context allows. Later callers
+// get the cached identity, even while it is being refreshed after ttl.
+//
+// If p fails, the failure is cached too, and p is called again after
+// a backoff that starts at one second and doubles with every failure,
+// up to ttl. If p fails after an identity was cached, the cached
+// identity is provided until p succeeds again.
+func Cache(p Provider, ttl time.Duration) Provider {
+	return &cache{p: p, ttl: ttl, now: time.Now, first: make(chan struct{})}
+}
+
+type cache struct {
+	p   Provider
+	ttl time.Duration
+	now func() time.Time
+
+	// first is closed once the first fetch completes.
+	first chan struct{}
+	// wg tracks fetches, so that tests can wait for them.
+	wg sync.WaitGroup
+
+	mu       sync.Mutex
+	id       Identity
+	ok       bool
+	err      error
+	next     time.Time
+	backoff  time.Duration
+	fetching bool
+	fetched  bool
+}
+
+func (c *cache) Identity(ctx context.Context) (Identity, error) {
+	c.mu.Lock()
+	if !c.fetching && !c.now().Before(c.next) {
+		c.fetching = true
+		c.wg.Add(1)
+		go c.fetch()
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-c.first:
+	default:
+		select {
+		case <-c.first:
+		case <-ctx.Done():
+			return Identity{}, ctx.Err()
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ok {
+		return c.id, nil
+	}
+
+	return Identity{}, c.err
+}
+
+// fetch gets the identity from the Provider and caches it, or caches
+// its failure, without holding c.mu while the Provider is called.
+func (c *cache) fetch() {
+	defer c.wg.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	id, err := c.p.Identity(ctx)
+	cancel()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if err != nil {
+		c.err = err
+		switch {
+		case c.backoff == 0:
+			c.backoff = minBackoff
+		case c.backoff < c.ttl:
+			c.backoff *= 2
+		}
+		if c.backoff > c.ttl {
+			c.backoff = c.ttl
+		}
+		c.next = now.Add(c.backoff)
+	} else {
+		c.id, c.ok, c.err = id, true, nil
+		c.backoff = 0
+		c.next = now.Add(c.ttl)
+	}
+
+	c.fetching = false
+	if !c.fetched {
+		c.fetched = true
+		close(c.first)
+	}
+}
+
+// NewHook returns a Hook that adds the fields of the identity
+// provided by p to every event that does not have them. Events
+// are logged without them if p fails. p is called on every event,
+// with a timeout of 2 seconds, so it should usually be wrapped
+// with Cache, as the "identity" hook does.
+func NewHook(p Provider) slog.Hook {
+	return slog.HookFunc(func(e *slog.Event) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		id, err := p.Identity(ctx)
+		if err != nil {
+			return
+		}
+
+		for k, v := range id.Fields() {
+			if _, ok := e.Fields[k]; !ok {
+				e.Fields[k] = v
+			}
+		}
+	})
+}
+
+func init() {
+	slog.RegisterHook("identity", func(params map[string]string) (slog.Hook, error) {
+		var p Provider
+		switch params["provider"] {
+		case "", "host":
+			p = Host
+		case "ec2":
+			p = &EC2{}
+		case "gce":
+			p = &GCE{}
+		case "azure":
+			p = &Azure{}
+		default:
+			return nil, fmt.Errorf("identity: unknown provider %q", params["provider"])
+		}
+
+		ttl := DefaultTTL
+		if s := params["ttl"]; s != "" {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return nil, fmt.Errorf("identity: ttl: %w", err)
+			}
+			ttl = d
+		}
+
+		return NewHook(Cache(p, ttl)), nil
+	})
+}
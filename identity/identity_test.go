@@ -0,0 +1,297 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/safe-waters/slog"
+)
+
+func TestProviders(t *testing.T) {
+	t.Parallel()
+
+	ec2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/latest/api/token" {
+			if r.Method != http.MethodPut || r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte("token"))
+			return
+		}
+
+		if r.Header.Get("X-aws-ec2-metadata-token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte(map[string]string{
+			"/latest/meta-data/local-hostname":              "ip-10-0-0-1.ec2.internal",
+			"/latest/meta-data/instance-id":                 "i-0123456789abcdef0",
+			"/latest/meta-data/placement/availability-zone": "us-east-1a",
+		}[r.URL.Path]))
+	}))
+	t.Cleanup(ec2.Close)
+
+	gce := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.Write([]byte(map[string]string{
+			"/computeMetadata/v1/instance/hostname": "vm.c.project.internal",
+			"/computeMetadata/v1/instance/id":       "4520031799277581759",
+			"/computeMetadata/v1/instance/zone":     "projects/123/zones/us-central1-a",
+		}[r.URL.Path]))
+	}))
+	t.Cleanup(gce.Close)
+
+	azure := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Path != "/metadata/instance/compute" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{
+			"name":     "vm",
+			"vmId":     "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+			"location": "westeurope",
+			"zone":     "1",
+		})
+	}))
+	t.Cleanup(azure.Close)
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		p    Provider
+		exp  Identity
+	}{
+		{name: "host", p: Host, exp: Identity{Hostname: hostname}},
+		{
+			name: "ec2",
+			p:    &EC2{Endpoint: ec2.URL},
+			exp:  Identity{Hostname: "ip-10-0-0-1.ec2.internal", InstanceID: "i-0123456789abcdef0", Zone: "us-east-1a"},
+		},
+		{
+			name: "gce",
+			p:    &GCE{Endpoint: gce.URL + "/"},
+			exp:  Identity{Hostname: "vm.c.project.internal", InstanceID: "4520031799277581759", Zone: "us-central1-a"},
+		},
+		{
+			name: "azure",
+			p:    &Azure{Endpoint: azure.URL},
+			exp:  Identity{Hostname: "vm", InstanceID: "02aab8a4-74ef-476e-8182-f6d2ba4166a6", Zone: "westeurope-1"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			id, err := test.p.Identity(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if id != test.exp {
+				t.Fatalf("expected identity '%+v', got '%+v'", test.exp, id)
+			}
+		})
+	}
+}
+
+func TestProviderErrors(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	for _, p := range []Provider{&EC2{Endpoint: srv.URL}, &GCE{Endpoint: srv.URL}, &Azure{Endpoint: srv.URL}} {
+		if _, err := p.Identity(context.Background()); err == nil {
+			t.Fatalf("expected an error from '%T', got nil", p)
+		}
+	}
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls int32
+		fail  int32
+	)
+
+	p := ProviderFunc(func(context.Context) (Identity, error) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&fail) == 1 {
+			return Identity{}, errors.New("unavailable")
+		}
+		return Identity{Hostname: "a"}, nil
+	})
+
+	var (
+		nowMu sync.Mutex
+		now   = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	c := Cache(p, time.Minute).(*cache)
+	c.now = func() time.Time {
+		nowMu.Lock()
+		defer nowMu.Unlock()
+		return now
+	}
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		fail    bool
+		calls   int32
+	}{
+		{name: "first", calls: 1},
+		{name: "cached", advance: 30 * time.Second, calls: 1},
+		{name: "expired", advance: time.Minute, calls: 2},
+		{name: "stale on failure", advance: time.Minute, fail: true, calls: 3},
+		{name: "backoff", advance: 500 * time.Millisecond, fail: true, calls: 3},
+		{name: "after backoff", advance: time.Second, fail: true, calls: 4},
+		{name: "doubled backoff", advance: time.Second, calls: 4},
+		{name: "recovered", advance: time.Second, calls: 5},
+	}
+
+	for _, test := range tests {
+		nowMu.Lock()
+		now = now.Add(test.advance)
+		nowMu.Unlock()
+
+		if test.fail {
+			atomic.StoreInt32(&fail, 1)
+		} else {
+			atomic.StoreInt32(&fail, 0)
+		}
+
+		id, err := c.Identity(context.Background())
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		c.wg.Wait()
+
+		if n := atomic.LoadInt32(&calls); id.Hostname != "a" || n != test.calls {
+			t.Fatalf("%s: expected hostname '%s' after '%d' call(s), got '%s' after '%d'",
+				test.name, "a", test.calls, id.Hostname, n)
+		}
+	}
+}
+
+func TestCacheFailure(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+
+	c := Cache(ProviderFunc(func(context.Context) (Identity, error) {
+		atomic.AddInt32(&calls, 1)
+		return Identity{}, errors.New("unavailable")
+	}), time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Identity(context.Background()); err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("expected the failure to be cached after '%d' call, got '%d'", 1, n)
+	}
+}
+
+func TestCacheSlowProvider(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	c := Cache(ProviderFunc(func(context.Context) (Identity, error) {
+		<-release
+		return Identity{Hostname: "a"}, nil
+	}), time.Minute).(*cache)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.Identity(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected '%v' while the first fetch runs, got '%v'", context.DeadlineExceeded, err)
+	}
+
+	close(release)
+	c.wg.Wait()
+
+	if id, err := c.Identity(context.Background()); err != nil || id.Hostname != "a" {
+		t.Fatalf("expected hostname '%s', got '%s' and '%v'", "a", id.Hostname, err)
+	}
+}
+
+type linesWriter struct{ lines []string }
+
+func (w *linesWriter) Write(p []byte) (n int, err error) {
+	w.lines = append(w.lines, string(p))
+	return len(p), nil
+}
+
+func TestHook(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := slog.NewLogger(slog.WithOutput(w))
+	l.AddHook(NewHook(ProviderFunc(func(context.Context) (Identity, error) {
+		return Identity{Hostname: "a", Zone: "z"}, nil
+	})))
+
+	l.Infof(slog.Fields{"zone": "mine"}, "hello")
+
+	var e struct {
+		Fields map[string]string `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(w.lines[0]), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := map[string]string{"hostname": "a", "zone": "mine"}; !reflect.DeepEqual(e.Fields, exp) {
+		t.Fatalf("expected fields '%v', got '%v'", exp, e.Fields)
+	}
+}
+
+func TestRegisteredHook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		params map[string]string
+		err    bool
+	}{
+		{params: nil},
+		{params: map[string]string{"provider": "ec2", "ttl": "10m"}},
+		{params: map[string]string{"provider": "gce"}},
+		{params: map[string]string{"provider": "azure"}},
+		{params: map[string]string{"provider": "heroku"}, err: true},
+		{params: map[string]string{"ttl": "often"}, err: true},
+	}
+
+	for _, test := range tests {
+		if _, err := slog.NewHook("identity", test.params); (err != nil) != test.err {
+			t.Fatalf("expected error '%t' for params '%v', got '%v'", test.err, test.params, err)
+		}
+	}
+}
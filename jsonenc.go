@@ -0,0 +1,205 @@
+package slog
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// appendEvent appends e to dst as JSON, producing the same output as
+// encoding/json without using reflection. It is used by builds that
+// avoid encoding/json, such as TinyGo builds.
+func appendEvent(dst []byte, e *event) []byte {
+	dst = append(dst, `{"_metadata":`...)
+	dst = appendJSON(dst, e.Metadata)
+
+	if len(e.Fields) > 0 {
+		dst = append(dst, `,"fields":`...)
+		dst = appendJSON(dst, e.Fields)
+	}
+
+	dst = append(dst, `,"message":`...)
+	dst = appendJSON(dst, e.Message)
+
+	return append(dst, '}')
+}
+
+// appendReport appends r to dst as JSON, with its Duration as a string,
+// producing the same output as encoding/json does for PrintSummary.
+func appendReport(dst []byte, r Report) []byte {
+	dst = append(dst, `{"start":"`...)
+	dst = r.Start.AppendFormat(dst, time.RFC3339Nano)
+
+	dst = append(dst, `","counts":`...)
+	if r.Counts == nil {
+		dst = append(dst, "null"...)
+	} else {
+		counts := make(map[string]interface{}, len(r.Counts))
+		for lv, n := range r.Counts {
+			counts[lv] = n
+		}
+		dst = appendJSONObject(dst, counts)
+	}
+
+	dst = append(dst, `,"top_messages":`...)
+	if r.TopMessages == nil {
+		dst = append(dst, "null"...)
+	} else {
+		dst = append(dst, '[')
+		for i, mc := range r.TopMessages {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = append(dst, `{"message":`...)
+			dst = appendJSONString(dst, mc.Message)
+			dst = append(dst, `,"count":`...)
+			dst = strconv.AppendInt(dst, int64(mc.Count), 10)
+			dst = append(dst, '}')
+		}
+		dst = append(dst, ']')
+	}
+
+	dst = append(dst, `,"duration":`...)
+	dst = appendJSONString(dst, r.Duration.String())
+
+	return append(dst, '}')
+}
+
+// appendJSON appends v to dst as JSON. Values of types
+// it does not know are encoded as strings with fmt.Sprint.
+func appendJSON(dst []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(dst, "null"...)
+	case string:
+		return appendJSONString(dst, v)
+	case bool:
+		return strconv.AppendBool(dst, v)
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(dst, v, 10)
+	case uint:
+		return strconv.AppendUint(dst, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(dst, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(dst, v, 10)
+	case float64:
+		return appendJSONFloat(dst, v)
+	case Fields:
+		return appendJSONObject(dst, v)
+	case map[string]interface{}:
+		return appendJSONObject(dst, v)
+	case []string:
+		dst = append(dst, '[')
+		for i, s := range v {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, s)
+		}
+		return append(dst, ']')
+	case []interface{}:
+		dst = append(dst, '[')
+		for i, e := range v {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSON(dst, e)
+		}
+		return append(dst, ']')
+	default:
+		return appendJSONString(dst, fmt.Sprint(v))
+	}
+}
+
+// appendJSONFloat appends f to dst the way encoding/json does,
+// which uses exponents only for very small and very large values.
+func appendJSONFloat(dst []byte, f float64) []byte {
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+
+	dst = strconv.AppendFloat(dst, f, format, -1, 64)
+
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+
+	return dst
+}
+
+func appendJSONObject(dst []byte, m map[string]interface{}) []byte {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	dst = append(dst, '{')
+	for i, k := range keys {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendJSONString(dst, k)
+		dst = append(dst, ':')
+		dst = appendJSON(dst, m[k])
+	}
+
+	return append(dst, '}')
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s to dst as a JSON string, escaped
+// the same way as encoding/json, including its HTML escaping.
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b < utf8.RuneSelf {
+			switch {
+			case b == '"' || b == '\\':
+				dst = append(dst, '\\', b)
+			case b == '\n':
+				dst = append(dst, '\\', 'n')
+			case b == '\r':
+				dst = append(dst, '\\', 'r')
+			case b == '\t':
+				dst = append(dst, '\\', 't')
+			case b < 0x20 || b == '<' || b == '>' || b == '&':
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xf])
+			default:
+				dst = append(dst, b)
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			dst = append(dst, "\ufffd"...)
+		case r == '\u2028' || r == '\u2029':
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xf])
+		default:
+			dst = append(dst, s[i:i+size]...)
+		}
+		i += size
+	}
+
+	return append(dst, '"')
+}
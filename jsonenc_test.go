@@ -0,0 +1,109 @@
+package slog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAppendEvent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		e    *event
+	}{
+		{
+			name: "minimal",
+			e: &event{
+				Metadata: Fields{"level": "info", "file": "a.go:1", "time": "now"},
+				Message:  "hello",
+			},
+		},
+		{
+			name: "fields",
+			e: &event{
+				Metadata: Fields{"level": "info", "severity": 6, "big": int64(1 << 60), "i32": int32(-3), "u": uint(3), "u32": uint32(4), "u64": uint64(5), "ratio": 0.25, "million": 1e6, "tiny": 1e-7, "huge": -1e21},
+				Fields:   Fields{"b": "2", "a": "1", "nested": Fields{"z": "26", "y": true}},
+				Message:  "hello",
+			},
+		},
+		{
+			name: "arrays",
+			e: &event{
+				Metadata: Fields{"panic_chain": []string{"*a", "*b"}, "list": []interface{}{"a", 1, nil}},
+				Message:  "hello",
+			},
+		},
+		{
+			name: "escaping",
+			e: &event{
+				Metadata: Fields{"level": "info"},
+				Fields:   Fields{"k\"ey": "quote\" backslash\\ newline\n tab\t cr\r ctrl\x01 html<>& ls\u2028 ps\u2029 bad\xff é 世界"},
+				Message:  "<script>",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			exp, err := json.Marshal(test.e)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := appendEvent(nil, test.e); string(exp) != string(got) {
+				t.Fatalf("expected '%s', got '%s'", exp, got)
+			}
+		})
+	}
+}
+
+func TestAppendReport(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, 1, 2, 3, 4, 5, 600, time.FixedZone("", 3600))
+
+	tests := []struct {
+		name string
+		r    Report
+	}{
+		{name: "empty", r: Report{Start: start}},
+		{
+			name: "full",
+			r: Report{
+				Start:       start.UTC(),
+				Duration:    1500 * time.Millisecond,
+				Counts:      map[string]int{"info": 2, "error": 1},
+				TopMessages: []MessageCount{{Message: "<hello>", Count: 2}, {Message: "bye", Count: 1}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			exp, err := json.Marshal(struct {
+				Report
+				Duration string `json:"duration"`
+			}{
+				Report:   test.r,
+				Duration: test.r.Duration.String(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := appendReport(nil, test.r); string(exp) != string(got) {
+				t.Fatalf("expected '%s', got '%s'", exp, got)
+			}
+		})
+	}
+}
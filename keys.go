@@ -0,0 +1,122 @@
+package slog
+
+// Keys are the names of the keys of encoded events, so that the output
+// of a Logger can match an existing schema. Empty names keep
+// their defaults, which are "_metadata", "fields", and "message", and
+// "level", "time", "file", "function", "event_id", "fingerprint",
+// and "annotates" in the metadata.
+type Keys struct {
+	// Metadata, Fields, and Message are the top-level keys.
+	Metadata string
+	Fields   string
+	Message  string
+
+	// Level, Time, File, Function, EventID, Fingerprint, Logger,
+	// Goroutine, Seq, and Annotates are keys of the metadata.
+	Level       string
+	Time        string
+	File        string
+	Function    string
+	EventID     string
+	Fingerprint string
+	Logger      string
+	Goroutine   string
+	Seq         string
+	Annotates   string
+}
+
+// standardKeys are the keys of encoded events by default.
+var standardKeys = Keys{
+	Metadata:    "_metadata",
+	Fields:      "fields",
+	Message:     "message",
+	Level:       "level",
+	Time:        "time",
+	File:        "file",
+	Function:    "function",
+	EventID:     "event_id",
+	Fingerprint: "fingerprint",
+	Logger:      "logger",
+	Goroutine:   "goroutine",
+	Seq:         "seq",
+	Annotates:   "annotates",
+}
+
+// WithKeys renames the keys of the events the Logger encodes, for
+// example to log the message as "msg" and the metadata as "meta":
+//
+//	l := slog.NewLogger(slog.WithKeys(slog.Keys{Metadata: "meta", Message: "msg"}))
+//
+// When the top-level keys are renamed, they are encoded in sorted order.
+// Sinks and tools that decode events, such as the config package's
+// routes and pipelines, expect the default keys.
+func WithKeys(k Keys) Option {
+	return func(l *Logger) {
+		d := k.WithDefaults()
+		l.keys = &d
+	}
+}
+
+// WithDefaults returns k with its empty names replaced by their
+// defaults, which are the names used by a Logger created with
+// WithKeys(k).
+func (k Keys) WithDefaults() Keys {
+	d := standardKeys
+	for _, p := range []struct {
+		dst *string
+		v   string
+	}{
+		{&d.Metadata, k.Metadata},
+		{&d.Fields, k.Fields},
+		{&d.Message, k.Message},
+		{&d.Level, k.Level},
+		{&d.Time, k.Time},
+		{&d.File, k.File},
+		{&d.Function, k.Function},
+		{&d.EventID, k.EventID},
+		{&d.Fingerprint, k.Fingerprint},
+		{&d.Logger, k.Logger},
+		{&d.Goroutine, k.Goroutine},
+		{&d.Seq, k.Seq},
+		{&d.Annotates, k.Annotates},
+	} {
+		if p.v != "" {
+			*p.dst = p.v
+		}
+	}
+
+	return d
+}
+
+// keyNames returns the keys of the Logger's events.
+func (l *Logger) keyNames() *Keys {
+	if l.keys == nil {
+		return &standardKeys
+	}
+
+	return l.keys
+}
+
+// marshal encodes e with the top-level keys of k,
+// or flattened if flat is true.
+func marshal(e *event, k *Keys, flat bool) []byte {
+	if flat {
+		return marshalFields(flatten(e, k))
+	}
+
+	if k.Metadata == standardKeys.Metadata &&
+		k.Fields == standardKeys.Fields &&
+		k.Message == standardKeys.Message {
+		return marshalEvent(e)
+	}
+
+	f := Fields{
+		k.Metadata: e.Metadata,
+		k.Message:  e.Message,
+	}
+	if len(e.Fields) > 0 {
+		f[k.Fields] = e.Fields
+	}
+
+	return marshalFields(f)
+}
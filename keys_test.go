@@ -0,0 +1,76 @@
+package slog
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKeys(t *testing.T) {
+	t.Parallel()
+
+	clock := func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name string
+		keys Keys
+		exp  string
+	}{
+		{
+			name: "defaults",
+			exp: `{"_metadata":{"event_id":"1","level":"info","time":"2021-01-01T00:00:00Z"},` +
+				`"fields":{"a":"b"},"message":"hello"}`,
+		},
+		{
+			name: "top-level",
+			keys: Keys{Metadata: "meta", Message: "msg", Fields: "attrs"},
+			exp: `{"attrs":{"a":"b"},"meta":{"event_id":"1","level":"info","time":"2021-01-01T00:00:00Z"},` +
+				`"msg":"hello"}`,
+		},
+		{
+			name: "metadata",
+			keys: Keys{Level: "severity", Time: "@timestamp", EventID: "id"},
+			exp: `{"_metadata":{"@timestamp":"2021-01-01T00:00:00Z","id":"1","severity":"info"},` +
+				`"fields":{"a":"b"},"message":"hello"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			l := NewLogger(WithOutput(mw), WithDeterministic(clock), WithKeys(test.keys))
+			l.Infof(Fields{"a": "b"}, "hello")
+
+			if got := strings.TrimSpace(string(mw.byt)); got != test.exp {
+				t.Fatalf("expected '%s', got '%s'", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestKeysChild(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw), WithKeys(Keys{Message: "msg", File: "caller", Logger: "component"}))
+	l.Named("db").Info("hello")
+
+	var e struct {
+		Metadata map[string]string `json:"_metadata"`
+		Msg      string            `json:"msg"`
+	}
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Msg != "hello" ||
+		!strings.HasPrefix(e.Metadata["caller"], "keys_test.go:") ||
+		e.Metadata["component"] != "db" {
+		t.Fatalf("expected renamed keys, got '%s'", mw.byt)
+	}
+}
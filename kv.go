@@ -0,0 +1,124 @@
+package slog
+
+import (
+	"fmt"
+	"os"
+)
+
+// missingValue is the value of a trailing key without a value.
+const missingValue = "<missing>"
+
+// keyValues returns the alternating keys and values of kv as Fields.
+// Keys that are not strings are formatted with fmt.Sprint, and a
+// trailing key without a value gets the value "<missing>". A strict
+// Logger panics on both instead.
+func (l *Logger) keyValues(kv []interface{}) Fields {
+	if len(kv) == 0 {
+		return nil
+	}
+
+	strict := l.isStrict()
+	if strict && len(kv)%2 != 0 {
+		panic(fmt.Sprintf("slog: strict testing: key %v without a value", kv[len(kv)-1]))
+	}
+
+	f := make(Fields, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		k, ok := kv[i].(string)
+		if !ok {
+			if strict {
+				panic(fmt.Sprintf("slog: strict testing: key %v of type %T is not a string", kv[i], kv[i]))
+			}
+			k = fmt.Sprint(kv[i])
+		}
+
+		if i+1 < len(kv) {
+			f[k] = kv[i+1]
+		} else {
+			f[k] = missingValue
+		}
+	}
+
+	return f
+}
+
+// Tracew calls the default Logger's Tracew method.
+func Tracew(msg interface{}, kv ...interface{}) {
+	defaultLogger.Tracew(msg, kv...)
+}
+
+// Debugw calls the default Logger's Debugw method.
+func Debugw(msg interface{}, kv ...interface{}) {
+	defaultLogger.Debugw(msg, kv...)
+}
+
+// Infow calls the default Logger's Infow method.
+func Infow(msg interface{}, kv ...interface{}) {
+	defaultLogger.Infow(msg, kv...)
+}
+
+// Warnw calls the default Logger's Warnw method.
+func Warnw(msg interface{}, kv ...interface{}) {
+	defaultLogger.Warnw(msg, kv...)
+}
+
+// Errorw calls the default Logger's Errorw method.
+func Errorw(msg interface{}, kv ...interface{}) {
+	defaultLogger.Errorw(msg, kv...)
+}
+
+// Panicw calls the default Logger's Panicw method.
+func Panicw(msg interface{}, kv ...interface{}) {
+	defaultLogger.Panicw(msg, kv...)
+}
+
+// Fatalw calls the default Logger's Fatalw method.
+func Fatalw(msg interface{}, kv ...interface{}) {
+	defaultLogger.Fatalw(msg, kv...)
+}
+
+// Tracew logs a message and fields given as alternating keys and
+// values at the trace level, which is terser than Fields for a few keys:
+//
+//	l.Infow("request", "method", "GET", "status", 200)
+func (l *Logger) Tracew(msg interface{}, kv ...interface{}) {
+	l.log(TraceLevel, l.keyValues(kv), msg)
+}
+
+// Debugw logs a message and fields given as alternating keys and values
+// at the debug level.
+func (l *Logger) Debugw(msg interface{}, kv ...interface{}) {
+	l.log(DebugLevel, l.keyValues(kv), msg)
+}
+
+// Infow logs a message and fields given as alternating keys and values
+// at the info level.
+func (l *Logger) Infow(msg interface{}, kv ...interface{}) {
+	l.log(InfoLevel, l.keyValues(kv), msg)
+}
+
+// Warnw logs a message and fields given as alternating keys and values
+// at the warn level.
+func (l *Logger) Warnw(msg interface{}, kv ...interface{}) {
+	l.log(WarnLevel, l.keyValues(kv), msg)
+}
+
+// Errorw logs a message and fields given as alternating keys and values
+// at the error level.
+func (l *Logger) Errorw(msg interface{}, kv ...interface{}) {
+	l.log(ErrorLevel, l.keyValues(kv), msg)
+}
+
+// Panicw logs a message and fields given as alternating keys and values
+// at the panic level and then panics with a *PanicError that holds
+// the message.
+func (l *Logger) Panicw(msg interface{}, kv ...interface{}) {
+	l.log(PanicLevel, l.keyValues(kv), msg)
+}
+
+// Fatalw logs a message and fields given as alternating keys and values
+// at the fatal level followed by os.Exit(1).
+func (l *Logger) Fatalw(msg interface{}, kv ...interface{}) {
+	l.log(FatalLevel, l.keyValues(kv), msg)
+	os.Exit(1)
+}
@@ -0,0 +1,85 @@
+package slog
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeyValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		kv   []interface{}
+		exp  map[string]string
+	}{
+		{name: "none", exp: nil},
+		{name: "pairs", kv: []interface{}{"method", "GET", "status", 200}, exp: map[string]string{"method": "GET", "status": "200"}},
+		{name: "missing value", kv: []interface{}{"method", "GET", "status"}, exp: map[string]string{"method": "GET", "status": "<missing>"}},
+		{name: "non-string key", kv: []interface{}{1, "one"}, exp: map[string]string{"1": "one"}},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			NewLogger(WithOutput(mw)).Infow("request", test.kv...)
+
+			var e struct {
+				Fields map[string]string `json:"fields"`
+			}
+			if err := json.Unmarshal(mw.byt, &e); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(e.Fields, test.exp) {
+				t.Fatalf("expected fields '%v', got '%v'", test.exp, e.Fields)
+			}
+		})
+	}
+}
+
+func TestKeyValuesStrict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		kv   []interface{}
+		exp  string
+	}{
+		{name: "valid", kv: []interface{}{"a", "b"}},
+		{name: "missing value", kv: []interface{}{"a", "b", "c"}, exp: "key c without a value"},
+		{name: "non-string key", kv: []interface{}{1, "b"}, exp: "key 1 of type int is not a string"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := NewLogger(WithOutput(&mockWriter{}), WithStrictTesting())
+
+			defer func() {
+				r := recover()
+				if test.exp == "" {
+					if r != nil {
+						t.Fatalf("expected no panic, got '%v'", r)
+					}
+					return
+				}
+
+				if s, _ := r.(string); !strings.Contains(s, test.exp) {
+					t.Fatalf("expected a panic containing '%s', got '%v'", test.exp, r)
+				}
+			}()
+
+			l.Debugw("hello", test.kv...)
+		})
+	}
+}
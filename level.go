@@ -0,0 +1,249 @@
+package slog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	levelsMu sync.RWMutex
+	// levelSeverity orders the levels by severity.
+	levelSeverity = map[Level]int{
+		TraceLevel: 10,
+		DebugLevel: 20,
+		InfoLevel:  30,
+		WarnLevel:  40,
+		ErrorLevel: 50,
+		PanicLevel: 60,
+		FatalLevel: 70,
+	}
+)
+
+// RegisterLevel registers a custom level named name, such as "audit"
+// or "notice", with a severity that orders it among the other levels,
+// so that it interoperates with filtering. The built-in levels have
+// the severities trace 10, debug 20, info 30, warn 40, error 50,
+// panic 60, and fatal 70. For example, to add a level between
+// info and warn:
+//
+//	var NoticeLevel = slog.RegisterLevel("notice", 35)
+//
+// Events at custom levels are logged with Log. Custom levels with
+// a severity of at least 50 count as errors, for example for
+// FirstError.
+//
+// RegisterLevel panics if name is empty or already registered.
+func RegisterLevel(name string, severity int) Level {
+	lv := Level(name)
+
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	if name == "" {
+		panic("slog: RegisterLevel name is empty")
+	}
+
+	if _, ok := levelSeverity[lv]; ok {
+		panic(fmt.Sprintf("slog: RegisterLevel called twice for level %s", name))
+	}
+
+	levelSeverity[lv] = severity
+
+	return lv
+}
+
+// Severity returns the severity of lv, which orders it among the
+// other levels, and whether lv is registered.
+func (lv Level) Severity() (int, bool) {
+	return severityOf(lv)
+}
+
+// String returns the name of lv.
+func (lv Level) String() string {
+	return string(lv)
+}
+
+// ParseLevel returns the registered Level named s, ignoring case and
+// surrounding white space, so that configuration values such as "warn"
+// or "WARN" can be mapped onto a Logger's minimum level.
+// "warning" is accepted as WarnLevel.
+func ParseLevel(s string) (Level, error) {
+	name := strings.TrimSpace(s)
+	if strings.EqualFold(name, "warning") {
+		return WarnLevel, nil
+	}
+
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+
+	if _, ok := levelSeverity[Level(name)]; ok {
+		return Level(name), nil
+	}
+
+	for lv := range levelSeverity {
+		if strings.EqualFold(string(lv), name) {
+			return lv, nil
+		}
+	}
+
+	return "", fmt.Errorf("slog: unknown level %q", s)
+}
+
+// severityOf returns the severity of lv and true,
+// or false if lv is not registered.
+func severityOf(lv Level) (int, bool) {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+
+	s, ok := levelSeverity[lv]
+	return s, ok
+}
+
+// isErrorLevel reports whether lv is at least as severe as ErrorLevel.
+func isErrorLevel(lv Level) bool {
+	s, ok := severityOf(lv)
+	e, _ := severityOf(ErrorLevel)
+
+	return ok && s >= e
+}
+
+// SetLevel calls the default Logger's SetLevel method.
+func SetLevel(lv Level) {
+	defaultLogger.SetLevel(lv)
+}
+
+// SetLevel sets the minimum level of events the Logger logs.
+// Events below lv are discarded before they are built, so
+// suppressed calls are cheap. The default is TraceLevel,
+// which logs every event.
+//
+// Panic and Fatal still panic and exit when their
+// events are discarded.
+func (l *Logger) SetLevel(lv Level) {
+	l.mu.Lock()
+	old := l.cfg.level()
+	l.cfg.minLevel = lv
+	l.cfg.atomicLevel = nil
+	l.mu.Unlock()
+
+	l.configChanged("level", old, lv)
+}
+
+// AtomicLevel is a minimum level that can be shared by Loggers
+// and changed while they log, for example to raise the verbosity
+// of a running service. It is safe for concurrent use.
+//
+// The zero value is ready to use and set to TraceLevel,
+// the default level of a Logger.
+type AtomicLevel struct {
+	v atomic.Value
+}
+
+// NewAtomicLevel returns an AtomicLevel set to lv.
+func NewAtomicLevel(lv Level) *AtomicLevel {
+	a := &AtomicLevel{}
+	a.SetLevel(lv)
+
+	return a
+}
+
+// Level returns the current level.
+func (a *AtomicLevel) Level() Level {
+	lv, ok := a.v.Load().(Level)
+	if !ok {
+		return TraceLevel
+	}
+
+	return lv
+}
+
+// SetLevel changes the level. Loggers using a honor
+// the change from their next log call, and those with config
+// events on log it.
+func (a *AtomicLevel) SetLevel(lv Level) {
+	old := a.Level()
+	a.v.Store(lv)
+
+	notifyChange(a, "level", old, lv)
+}
+
+// SetAtomicLevel calls the default Logger's SetAtomicLevel method.
+func SetAtomicLevel(a *AtomicLevel) {
+	defaultLogger.SetAtomicLevel(a)
+}
+
+// SetAtomicLevel makes the Logger use a as its minimum level, reading
+// it on every log call, so that changes to a take effect immediately.
+// It replaces the level set with SetLevel, and a later call to SetLevel
+// replaces a.
+//
+// If a is nil, the Logger goes back to the level set with SetLevel.
+func (l *Logger) SetAtomicLevel(a *AtomicLevel) {
+	l.mu.Lock()
+	old := l.cfg.level()
+	l.cfg.atomicLevel = a
+	new := l.cfg.level()
+	l.mu.Unlock()
+
+	l.configChanged("level", old, new)
+}
+
+// level returns the minimum level of c.
+func (c config) level() Level {
+	if c.atomicLevel != nil {
+		return c.atomicLevel.Level()
+	}
+
+	return c.minLevel
+}
+
+// enabled reports whether the Logger logs events at lv.
+// Levels that are not registered are always logged.
+func (l *Logger) enabled(lv Level) bool {
+	l.mu.Lock()
+	min := l.cfg.level()
+	l.mu.Unlock()
+
+	if l.name != "" {
+		if nl, ok := namedLevel(l.name); ok {
+			min = nl
+		}
+	}
+
+	return atLeast(lv, min)
+}
+
+// atLeast reports whether lv is at least as severe as min.
+// Levels that are not registered are always at least min.
+func atLeast(lv, min Level) bool {
+	s, ok := severityOf(lv)
+	if !ok {
+		return true
+	}
+
+	minS, _ := severityOf(min)
+
+	return s >= minS
+}
+
+// Log calls the default Logger's Log method.
+func Log(lv Level, f Fields, msg interface{}) {
+	defaultLogger.Log(lv, f, msg)
+}
+
+// Log logs fields and a message at lv, which may be a built-in level
+// or one registered with RegisterLevel. Levels that are not registered
+// are always logged.
+//
+// Like Panicf and Fatalf, Log panics at PanicLevel
+// and exits at FatalLevel.
+func (l *Logger) Log(lv Level, f Fields, msg interface{}) {
+	l.log(lv, f, msg)
+
+	if lv == FatalLevel {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,70 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slog
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ServeHTTP reports and changes the level of a, so that operators can
+// turn up the verbosity of a running service during an incident.
+//
+// A GET request responds with the current level as JSON, such as
+// {"level":"info"}. A PUT request sets the level from a JSON body of
+// the same form or, if the body is a form, from its "level" value,
+// and responds with the new level. The level is parsed with ParseLevel.
+//
+// Changes are logged as config events by the Loggers that use a and
+// have them on, as with SetLevel.
+//
+// Invalid requests are answered with a JSON body such as
+// {"error":"..."} and status 400, or 405 for other methods.
+func (a *AtomicLevel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	type payload struct {
+		Level *Level `json:"level,omitempty"`
+		Error string `json:"error,omitempty"`
+	}
+
+	reply := func(status int, p payload) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(p)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var s string
+
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+			s = r.FormValue("level")
+		} else {
+			var p payload
+			if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+				reply(http.StatusBadRequest, payload{Error: "slog: invalid body: " + err.Error()})
+				return
+			}
+			if p.Level != nil {
+				s = string(*p.Level)
+			}
+		}
+
+		lv, err := ParseLevel(s)
+		if err != nil {
+			reply(http.StatusBadRequest, payload{Error: err.Error()})
+			return
+		}
+
+		a.SetLevel(lv)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		reply(http.StatusMethodNotAllowed, payload{Error: "slog: method " + r.Method + " not allowed"})
+		return
+	}
+
+	lv := a.Level()
+	reply(http.StatusOK, payload{Level: &lv})
+}
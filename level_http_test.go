@@ -0,0 +1,117 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAtomicLevelServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	a := NewAtomicLevel(InfoLevel)
+
+	tests := []struct {
+		method      string
+		contentType string
+		body        string
+		status      int
+		exp         string
+		lv          Level
+	}{
+		{method: http.MethodGet, status: http.StatusOK, exp: `{"level":"info"}`, lv: InfoLevel},
+		{
+			method: http.MethodPut,
+			body:   `{"level":"TRACE"}`,
+			status: http.StatusOK,
+			exp:    `{"level":"trace"}`,
+			lv:     TraceLevel,
+		},
+		{
+			method:      http.MethodPut,
+			contentType: "application/x-www-form-urlencoded",
+			body:        "level=warn",
+			status:      http.StatusOK,
+			exp:         `{"level":"warn"}`,
+			lv:          WarnLevel,
+		},
+		{
+			method: http.MethodPut,
+			body:   `{"level":"loud"}`,
+			status: http.StatusBadRequest,
+			exp:    `{"error":"slog: unknown level \"loud\""}`,
+			lv:     WarnLevel,
+		},
+		{
+			method: http.MethodPut,
+			body:   `level`,
+			status: http.StatusBadRequest,
+			lv:     WarnLevel,
+		},
+		{method: http.MethodPost, status: http.StatusMethodNotAllowed, lv: WarnLevel},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest(test.method, "/level", strings.NewReader(test.body))
+		if test.contentType != "" {
+			r.Header.Set("Content-Type", test.contentType)
+		}
+
+		w := httptest.NewRecorder()
+		a.ServeHTTP(w, r)
+
+		if w.Code != test.status {
+			t.Fatalf("expected status '%d' for %s '%s', got '%d'", test.status, test.method, test.body, w.Code)
+		}
+
+		if body := strings.TrimSpace(w.Body.String()); test.exp != "" && body != test.exp {
+			t.Fatalf("expected body '%s', got '%s'", test.exp, body)
+		}
+
+		if a.Level() != test.lv {
+			t.Fatalf("expected level '%s', got '%s'", test.lv, a.Level())
+		}
+	}
+}
+
+func TestAtomicLevelServeHTTPConfigEvents(t *testing.T) {
+	t.Parallel()
+
+	a := NewAtomicLevel(InfoLevel)
+
+	w := &syncLinesWriter{}
+	l := NewLogger(WithOutput(w))
+	l.SetAtomicLevel(a)
+	l.SetConfigEvents(true)
+
+	r := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"warn"}`))
+	a.ServeHTTP(httptest.NewRecorder(), r)
+
+	l.SetConfigEvents(false)
+
+	var e event
+	for _, line := range w.lines() {
+		var c event
+		if err := json.Unmarshal([]byte(line), &c); err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Fields["setting"] == "level" {
+			e = c
+			break
+		}
+	}
+
+	if e.Fields["setting"] != "level" || e.Fields["old"] != "info" || e.Fields["new"] != "warn" {
+		t.Fatalf("expected a level change from '%s' to '%s', got '%v'", "info", "warn", e.Fields)
+	}
+
+	if file, _ := e.Metadata["file"].(string); !strings.HasPrefix(file, "level_http.go:") {
+		t.Fatalf("expected file '%s', got '%s'", "level_http.go", file)
+	}
+}
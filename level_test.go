@@ -0,0 +1,249 @@
+package slog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSetLevel(t *testing.T) {
+	t.Parallel()
+
+	levels := []Level{TraceLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel, PanicLevel}
+
+	for _, min := range levels {
+		min := min
+
+		t.Run(string(min), func(t *testing.T) {
+			t.Parallel()
+
+			for _, lv := range levels {
+				var (
+					mw = &mockWriter{}
+					l  = NewLogger(WithOutput(mw))
+				)
+
+				l.SetLevel(min)
+				getLogFunc(t, l, lv, "hello")("hello")
+
+				logged := mw.byt != nil
+				lvS, _ := severityOf(lv)
+				minS, _ := severityOf(min)
+				if exp := lvS >= minS; logged != exp {
+					t.Fatalf(
+						"expected logged to be '%t' for level '%s' with minimum '%s', got '%t'",
+						exp,
+						lv,
+						min,
+						logged,
+					)
+				}
+
+				if !logged {
+					continue
+				}
+
+				var e event
+				if err := json.Unmarshal(mw.byt, &e); err != nil {
+					t.Fatal(err)
+				}
+
+				if e.Metadata["level"] != string(lv) {
+					t.Fatalf("expected level '%s', got '%s'", lv, e.Metadata["level"])
+				}
+			}
+		})
+	}
+}
+
+func TestAtomicLevel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a  = NewAtomicLevel(WarnLevel)
+		w1 = &linesWriter{}
+		w2 = &linesWriter{}
+		l1 = NewLogger(WithOutput(w1))
+		l2 = NewLogger(WithOutput(w2))
+	)
+
+	l1.SetAtomicLevel(a)
+	l2.SetAtomicLevel(a)
+
+	l1.Info("dropped")
+	l2.Info("dropped")
+
+	a.SetLevel(InfoLevel)
+
+	l1.Info("logged")
+	l2.Info("logged")
+
+	if len(w1.lines) != 1 || len(w2.lines) != 1 {
+		t.Fatalf("expected one line per Logger, got '%d' and '%d'", len(w1.lines), len(w2.lines))
+	}
+
+	l1.SetLevel(ErrorLevel)
+	l1.Warn("dropped")
+
+	if len(w1.lines) != 1 {
+		t.Fatalf("expected SetLevel to replace the AtomicLevel, got '%d' line(s)", len(w1.lines))
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			a.SetLevel([]Level{TraceLevel, ErrorLevel}[i%2])
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			l2.Info("racing")
+		}
+	}()
+
+	wg.Wait()
+}
+
+func TestAtomicLevelZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var a AtomicLevel
+	if lv := a.Level(); lv != TraceLevel {
+		t.Fatalf("expected level '%s', got '%s'", TraceLevel, lv)
+	}
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w))
+	l.SetAtomicLevel(&a)
+	l.Trace("logged")
+
+	if len(w.lines) != 1 {
+		t.Fatalf("expected one line, got '%d'", len(w.lines))
+	}
+}
+
+func TestSetLevelPanics(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw))
+	l.SetLevel(FatalLevel)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected Panic to panic when discarded, but it did not")
+		}
+
+		if mw.byt != nil {
+			t.Fatalf("expected nothing to be logged, got '%s'", mw.byt)
+		}
+	}()
+
+	l.Panic("hello")
+}
+
+var levelTestN int64
+
+func TestRegisterLevel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		n        = atomic.AddInt64(&levelTestN, 1)
+		notice   = RegisterLevel(fmt.Sprintf("notice-%d", n), 35)
+		critical = RegisterLevel(fmt.Sprintf("critical-%d", n), 55)
+		w        = &linesWriter{}
+		l        = NewLogger(WithOutput(w))
+	)
+
+	l.SetLevel(notice)
+
+	l.Info("dropped")
+	l.Log(notice, Fields{"k": "v"}, "kept")
+	l.Log(Level("unregistered"), nil, "kept")
+	l.Log(WarnLevel, nil, "kept")
+
+	if len(w.lines) != 3 {
+		t.Fatalf("expected '%d' line(s), got '%d'", 3, len(w.lines))
+	}
+
+	var e event
+	if err := json.Unmarshal([]byte(w.lines[0]), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Metadata["level"] != string(notice) || e.Fields["k"] != "v" {
+		t.Fatalf("expected a '%s' event with fields, got '%v'", notice, e)
+	}
+
+	if !strings.HasPrefix(fmt.Sprint(e.Metadata["file"]), "level_test.go:") {
+		t.Fatalf("expected file to contain '%s', got '%s'", "level_test.go", e.Metadata["file"])
+	}
+
+	if l.FirstError() != nil {
+		t.Fatal("expected no first error, got one")
+	}
+
+	l.Log(critical, nil, "failed")
+	if fe := l.FirstError(); fe == nil || fe.Level != critical {
+		t.Fatalf("expected a '%s' first error, got '%v'", critical, fe)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic when registering twice, got none")
+			}
+		}()
+		RegisterLevel(string(notice), 1)
+	}()
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+
+	custom := RegisterLevel(fmt.Sprintf("audit-%d", atomic.AddInt64(&levelTestN, 1)), 45)
+
+	tests := []struct {
+		s     string
+		exp   Level
+		isErr bool
+	}{
+		{s: "trace", exp: TraceLevel},
+		{s: "debug", exp: DebugLevel},
+		{s: "info", exp: InfoLevel},
+		{s: "warn", exp: WarnLevel},
+		{s: "WARNING", exp: WarnLevel},
+		{s: " Error\n", exp: ErrorLevel},
+		{s: "panic", exp: PanicLevel},
+		{s: "fatal", exp: FatalLevel},
+		{s: strings.ToUpper(string(custom)), exp: custom},
+		{s: "", isErr: true},
+		{s: "verbose", isErr: true},
+	}
+
+	for _, test := range tests {
+		lv, err := ParseLevel(test.s)
+		if test.isErr {
+			if err == nil {
+				t.Fatalf("expected an error for '%s', got level '%s'", test.s, lv)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if lv != test.exp {
+			t.Fatalf("expected level '%s' for '%s', got '%s'", test.exp, test.s, lv)
+		}
+	}
+}
@@ -3,13 +3,14 @@
 package slog
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -23,7 +24,7 @@ import (
 //
 // If you would like to create a wrapper around this package,
 // you will need to create the Logger with DefaultCallDepth+1
-// when calling New.
+// when calling New or with WithCallDepth.
 //
 // For more information, see the documentation for the standard
 // library's runtime.Caller function.
@@ -31,20 +32,175 @@ const DefaultCallDepth = 3
 
 // Logger is a wrapper around the standard library's log.Logger.
 // It produces structured log messages as JSON key-value string pairs
-// and has the levels, "trace", "info", "warn", "error", "panic",
-// and "fatal".
+// and has the levels, "trace", "debug", "info", "warn", "error",
+// "panic", and "fatal".
 //
-// It always logs the level, file name, line number, and timestamp
-// in unix nano seconds (UTC) as metadata.
+// It always logs the level and the time, in UTC by default, as metadata, and the file
+// name and line number unless the Logger is created with WithCaller(false).
+// The time is formatted as RFC 3339 with nanoseconds, or as an integer
+// number of Unix nanoseconds if the Logger is created with
+// WithUnixNanoTime.
 type Logger struct {
 	callDepth       int
 	logger          *log.Logger
 	permanentFields Fields
+
+	// name is the dotted name given with Named, if any.
+	name string
+
+	// noCaller is set with WithCaller(false).
+	noCaller bool
+
+	// callerFullPath and callerFunction are set with WithCallerFullPath
+	// and WithCallerFunction.
+	callerFullPath bool
+	callerFunction bool
+
+	// goroutineID is set with WithGoroutineID.
+	goroutineID bool
+
+	// clock, if non-nil, replaces time.Now.
+	clock func() time.Time
+
+	// localTime is set with WithLocalTime.
+	localTime bool
+
+	// strict is set with WithStrictTesting.
+	strict bool
+
+	// keys, if non-nil, are set with WithKeys.
+	keys *Keys
+
+	// flat is set with WithFlat.
+	flat bool
+
+	// stripANSI is set with WithStripANSI.
+	stripANSI bool
+
+	// unixNano is set with WithUnixNanoTime.
+	unixNano bool
+
+	// seq, if non-nil, counts the events written, as set with
+	// WithSequence. It is shared with derived Loggers.
+	seq *uint64
+
+	// targets is set with WithTargets.
+	targets *Targets
+
+	// run holds the metadata set with WithRunID, WithRestartCount,
+	// WithProcessInfo, and WithBuildInfo.
+	run Fields
+
+	lc       *lifecycle
+	fallback io.Writer
+
+	mu  sync.Mutex
+	cfg config
+
+	// rec is shared with the Loggers derived from this one.
+	rec *recorder
+}
+
+// recorder holds what a Logger and the Loggers derived from it have
+// logged, for FirstError and Summary, and the events held while they
+// are paused.
+type recorder struct {
+	mu       sync.Mutex
+	start    time.Time
+	firstErr *Event
+	counts   map[string]int
+	messages map[string]int
+
+	// paused is non-nil while the Loggers are paused.
+	paused  []string
+	dropped int
+
+	// deprecations holds the call sites of deprecated APIs
+	// that were reported with Deprecated.
+	deprecations map[string]bool
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		start:        time.Now().UTC(),
+		counts:       make(map[string]int),
+		messages:     make(map[string]int),
+		deprecations: make(map[string]bool),
+	}
+}
+
+// config holds the settings of a Logger that may change
+// after it is created. It is guarded by Logger.mu.
+type config struct {
+	hooks []Hook
+	idGen IDGenerator
+
+	// fingerprintKeys is non-nil when fingerprinting is enabled.
+	fingerprintKeys []string
+
+	minLevel Level
+
+	// atomicLevel, if non-nil, overrides minLevel.
+	atomicLevel *AtomicLevel
+
+	// dupes is non-nil in development mode.
+	dupes *dupDetector
+
+	severityKey string
+	severity    SeverityTable
+
+	// sampler is non-nil when sampling is enabled.
+	sampler *sampler
+
+	// cardinality is non-nil when the cardinality guard is enabled.
+	cardinality *cardinalityGuard
+
+	// annotations is non-nil when annotations are enabled.
+	annotations *correlationCache
+
+	// defaults is non-nil when field defaults are set.
+	defaults Fields
+
+	// aggregates holds the aggregators of messages, if any.
+	aggregates map[string]*aggregator
+
+	configEvents bool
+	errorStacks  bool
+	errorCauses  bool
 }
 
 // Fields holds key-value pairs for logs.
+//
+// Values of type Fields group related fields under one key and are
+// logged as nested objects, for example:
+//
+//	l.Infof(slog.Fields{"http": slog.Fields{"method": "GET", "status": 200}}, "request")
+//
+// logs the fields {"http":{"method":"GET","status":"200"}}.
 type Fields map[string]interface{}
 
+// An Option configures a Logger created with NewLogger.
+type Option func(l *Logger)
+
+// NewLogger returns a Logger configured by opts. Without options, it
+// writes to os.Stdout, logs every level, and logs the file name and line
+// number of the caller.
+func NewLogger(opts ...Option) *Logger {
+	l := &Logger{
+		callDepth: DefaultCallDepth,
+		logger:    log.New(os.Stdout, "", 0),
+		lc:        &lifecycle{},
+		cfg:       config{minLevel: TraceLevel},
+		rec:       newRecorder(),
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
 // New returns a Logger that determines the file name and line number
 // from callDepth, where to write out, and fields to permanently set that will
 // appear with every log.
@@ -54,27 +210,133 @@ type Fields map[string]interface{}
 // If permanentFields contains a key that is equal to
 // a key in another method such as Infof, the permanentFields
 // value will take priority.
+//
+// New is a shorthand for NewLogger with the options WithCallDepth,
+// WithOutput, and WithFields, which NewLogger can combine with others.
 func New(callDepth int, out io.Writer, permanentFields Fields) *Logger {
-	if out == nil {
-		out = os.Stdout
+	return NewLogger(WithCallDepth(callDepth), WithOutput(out), WithFields(permanentFields))
+}
+
+// WithCallDepth sets the number of stack frames to ascend to find the
+// caller, which is DefaultCallDepth by default.
+func WithCallDepth(callDepth int) Option {
+	return func(l *Logger) {
+		l.callDepth = callDepth
+	}
+}
+
+// WithOutput makes the Logger write to out.
+// If out is nil, it will default to os.Stdout.
+func WithOutput(out io.Writer) Option {
+	return func(l *Logger) {
+		if out == nil {
+			out = os.Stdout
+		}
+
+		l.logger.SetOutput(out)
 	}
+}
 
-	return &Logger{
-		callDepth:       callDepth,
-		logger:          log.New(out, "", 0),
-		permanentFields: permanentFields,
+// WithFields sets fields that will appear with every log.
+// If f contains a key that is equal to a key in another method
+// such as Infof, the value in f will take priority.
+func WithFields(f Fields) Option {
+	return func(l *Logger) {
+		l.permanentFields = f
 	}
 }
 
-type level string
+// WithLevel sets the minimum level of events the Logger logs,
+// like SetLevel.
+func WithLevel(lv Level) Option {
+	return func(l *Logger) {
+		l.cfg.minLevel = lv
+	}
+}
 
+// WithDeterministic makes the Logger produce byte-identical output
+// for the same sequence of calls, for example so that replicated state
+// machines that log while applying entries can be compared across
+// replicas to detect divergence. The Logger takes the time of events
+// from clock, which should derive it from replicated state, such as the
+// time stamped on the entry being applied. It stamps events with an
+// "event_id" from a SequenceGenerator, and does not log the caller.
+// Fields and metadata are always encoded with sorted keys.
+//
+// Features whose output depends on timing, such as SetSampling,
+// should not be used in deterministic mode.
+func WithDeterministic(clock func() time.Time) Option {
+	return func(l *Logger) {
+		l.clock = clock
+		l.cfg.idGen = &SequenceGenerator{}
+		l.noCaller = true
+	}
+}
+
+// WithClock makes the Logger take the time of events from clock instead
+// of time.Now, for example so that tests can compare times exactly.
+func WithClock(clock func() time.Time) Option {
+	return func(l *Logger) {
+		l.clock = clock
+	}
+}
+
+// WithLocalTime makes the Logger log times in the local time zone,
+// with its offset from UTC, instead of in UTC.
+func WithLocalTime() Option {
+	return func(l *Logger) {
+		l.localTime = true
+	}
+}
+
+// WithCallerFullPath makes the Logger log the full path of the caller's
+// file, such as "/src/app/db/conn.go:42", instead of its base name,
+// which is ambiguous when packages have files with the same name.
+func WithCallerFullPath() Option {
+	return func(l *Logger) {
+		l.callerFullPath = true
+	}
+}
+
+// WithCallerFunction makes the Logger log the name of the calling
+// function, qualified by its package path, such as
+// "github.com/org/app/db.(*Conn).Query", as "function" in the metadata.
+func WithCallerFunction() Option {
+	return func(l *Logger) {
+		l.callerFunction = true
+	}
+}
+
+// WithUnixNanoTime makes the Logger log the time of events as an integer
+// number of nanoseconds since the Unix epoch, such as 1609459200000000000,
+// instead of as an RFC 3339 string, for consumers that sort numerically.
+func WithUnixNanoTime() Option {
+	return func(l *Logger) {
+		l.unixNano = true
+	}
+}
+
+// WithCaller turns logging the file name and line number of the caller
+// on or off. It is on by default. Turning it off omits "file" from
+// the metadata and saves looking up the caller on every call.
+func WithCaller(enabled bool) Option {
+	return func(l *Logger) {
+		l.noCaller = !enabled
+	}
+}
+
+// Level is the severity of an event.
+type Level string
+
+// The levels, in increasing order of severity.
 const (
-	traceLevel level = "trace"
-	infoLevel  level = "info"
-	warnLevel  level = "warn"
-	errorLevel level = "error"
-	panicLevel level = "panic"
-	fatalLevel level = "fatal"
+	TraceLevel Level = "trace"
+	DebugLevel Level = "debug"
+	InfoLevel  Level = "info"
+	WarnLevel  Level = "warn"
+	ErrorLevel Level = "error"
+	PanicLevel Level = "panic"
+	FatalLevel Level = "fatal"
 )
 
 var defaultLogger = New(DefaultCallDepth+1, os.Stdout, nil)
@@ -89,6 +351,16 @@ func Tracef(f Fields, msg interface{}) {
 	defaultLogger.Tracef(f, msg)
 }
 
+// Debug calls the default Logger's Debug method.
+func Debug(msg interface{}) {
+	defaultLogger.Debug(msg)
+}
+
+// Debugf calls the default Logger's Debugf method.
+func Debugf(f Fields, msg interface{}) {
+	defaultLogger.Debugf(f, msg)
+}
+
 // Info calls the default Logger's Info method.
 func Info(msg interface{}) {
 	defaultLogger.Info(msg)
@@ -141,123 +413,502 @@ func Fatalf(f Fields, msg interface{}) {
 
 // Trace logs a message at the trace level.
 func (l *Logger) Trace(msg interface{}) {
-	l.log(traceLevel, nil, msg)
+	l.log(TraceLevel, nil, msg)
 }
 
 // Tracef logs fields and a message at the trace level.
 func (l *Logger) Tracef(f Fields, msg interface{}) {
-	l.log(traceLevel, f, msg)
+	l.log(TraceLevel, f, msg)
+}
+
+// Debug logs a message at the debug level.
+func (l *Logger) Debug(msg interface{}) {
+	l.log(DebugLevel, nil, msg)
+}
+
+// Debugf logs fields and a message at the debug level.
+func (l *Logger) Debugf(f Fields, msg interface{}) {
+	l.log(DebugLevel, f, msg)
 }
 
 // Info logs a message at the info level.
 func (l *Logger) Info(msg interface{}) {
-	l.log(infoLevel, nil, msg)
+	l.log(InfoLevel, nil, msg)
 }
 
 // Infof logs fields and a message at the info level.
 func (l *Logger) Infof(f Fields, msg interface{}) {
-	l.log(infoLevel, f, msg)
+	l.log(InfoLevel, f, msg)
 }
 
 // Warn logs a message at the warn level.
 func (l *Logger) Warn(msg interface{}) {
-	l.log(warnLevel, nil, msg)
+	l.log(WarnLevel, nil, msg)
 }
 
 // Warnf logs fields and a message at the warn level.
 func (l *Logger) Warnf(f Fields, msg interface{}) {
-	l.log(warnLevel, f, msg)
+	l.log(WarnLevel, f, msg)
 }
 
 // Error logs a message at the error level.
 func (l *Logger) Error(msg interface{}) {
-	l.log(errorLevel, nil, msg)
+	l.log(ErrorLevel, nil, msg)
 }
 
 // Errorf logs fields and a message at the error level.
 func (l *Logger) Errorf(f Fields, msg interface{}) {
-	l.log(errorLevel, f, msg)
+	l.log(ErrorLevel, f, msg)
 }
 
-// Panic logs a message at the panic level and then panics with the message.
+// Panic logs a message at the panic level and then panics with
+// a *PanicError that holds the message.
 func (l *Logger) Panic(msg interface{}) {
-	l.log(panicLevel, nil, msg)
+	l.log(PanicLevel, nil, msg)
 }
 
-// Panicf logs fields and a message at the panic level and then panics with the fields and message.
+// Panicf logs fields and a message at the panic level and then panics with
+// a *PanicError that holds the message.
+//
+// Events at the panic level also have "panic_type" in their metadata,
+// which holds the type of msg, and if msg is an error that wraps
+// other errors, "panic_chain", which holds the types of its
+// wrapped error chain, outermost first.
 func (l *Logger) Panicf(f Fields, msg interface{}) {
-	l.log(panicLevel, f, msg)
+	l.log(PanicLevel, f, msg)
 }
 
 // Fatal logs a message at the fatal level followed by os.Exit(1).
 func (l *Logger) Fatal(msg interface{}) {
-	l.log(fatalLevel, nil, msg)
+	l.log(FatalLevel, nil, msg)
 	os.Exit(1)
 }
 
 // Fatalf logs fields and a message at the fatal level followed by os.Exit(1).
 func (l *Logger) Fatalf(f Fields, msg interface{}) {
-	l.log(fatalLevel, f, msg)
+	l.log(FatalLevel, f, msg)
 	os.Exit(1)
 }
 
+// Event is a log event as recorded by a Logger.
+type Event struct {
+	ID      string
+	Level   Level
+	File    string
+	Time    time.Time
+	Fields  Fields
+	Message string
+
+	// Fingerprint is set when fingerprinting is enabled
+	// with SetFingerprint.
+	Fingerprint string
+
+	// Metadata holds additional metadata, if any.
+	Metadata Fields
+}
+
+// Clone returns a copy of e that shares no Fields or Metadata with e.
+func (e *Event) Clone() *Event {
+	c := *e
+	c.Fields = cloneFields(e.Fields)
+	c.Metadata = cloneFields(e.Metadata)
+
+	return &c
+}
+
+func cloneFields(f Fields) Fields {
+	if f == nil {
+		return nil
+	}
+
+	c := make(Fields, len(f))
+	for k, v := range f {
+		if g, ok := v.(Fields); ok {
+			v = cloneFields(g)
+		}
+		c[k] = v
+	}
+
+	return c
+}
+
+// Reemit logs a copy of e at level lv, for example from a Hook that
+// escalates a warning to an error. The copy keeps e's time, file,
+// fields, and message, and gets a new ID if event IDs are enabled.
+//
+// Hooks do not fire for the copy, and Reemit does not panic or exit
+// when lv is PanicLevel or FatalLevel.
+func (l *Logger) Reemit(e *Event, lv Level) {
+	if !l.enabled(lv) {
+		return
+	}
+
+	cfg := l.getConfig()
+
+	c := e.Clone()
+	c.Level = lv
+
+	if c.ID != "" && cfg.idGen != nil {
+		c.ID = cfg.idGen.NewID()
+	}
+
+	cfg.addSeverity(c)
+
+	l.record(c)
+	cfg.annotations.remember(c)
+	l.write(c)
+}
+
 type event struct {
 	Metadata Fields      `json:"_metadata"`
 	Fields   Fields      `json:"fields,omitempty"`
 	Message  interface{} `json:"message"`
 }
 
-func (l *Logger) log(lv level, f Fields, msg interface{}) {
-	combinedFields := Fields{}
+func (l *Logger) log(lv Level, f Fields, msg interface{}) {
+	l.logEvent(nil, 0, lv, f, nil, msg)
+}
+
+// logs is like log, with the fields given as Fields, and skips
+// skip more stack frames to find the caller.
+func (l *Logger) logs(skip int, lv Level, fs []Field, msg interface{}) {
+	l.logEvent(nil, skip, lv, nil, fs, msg)
+}
+
+// logEvent logs msg and the fields f or fs at lv, if the Logger is
+// enabled for lv or ctx, which may be nil, sets a minimum level that lv
+// meets, and returns the encoded event, or "" if none was written.
+// Only one of f and fs may be non-nil. It must be called directly
+// by log, logs, or logCtx, which must in turn be called directly by the
+// exported methods, so that the caller's file is found, after skipping
+// skip more stack frames.
+func (l *Logger) logEvent(ctx context.Context, skip int, lv Level, f Fields, fs []Field, msg interface{}) string {
+	if l.isStrict() {
+		if fs != nil {
+			l.checkMisuse(fieldsOf(fs))
+		} else {
+			l.checkMisuse(f)
+		}
+	}
+
+	var es string
+	if l.enabledCtx(ctx, lv) || l.targeted(lv, f, fs) {
+		keep, diag := l.sample(lv)
+		if keep || diag != nil {
+			var file, function string
+			if !l.noCaller {
+				file, function = l.fileInfo(1 + skip)
+			}
+
+			if diag != nil {
+				diag.File = file
+				l.write(diag)
+			}
+
+			if keep {
+				es = l.emit(skip, lv, file, function, f, fs, msg)
+			}
+		}
+	}
+
+	if lv == PanicLevel {
+		panic(&PanicError{Value: msg, Event: es})
+	}
+
+	return es
+}
+
+// emit builds an event from lv, file, function, f or fs, and msg, and
+// writes it out, returning the encoded event. function may be empty,
+// and only one of f and fs may be non-nil. Stack traces of errors in
+// the fields are taken as if emit was called by logEvent, skipping
+// skip more stack frames.
+func (l *Logger) emit(skip int, lv Level, file, function string, f Fields, fs []Field, msg interface{}) string {
+	f = resolveFields(f)
+
+	combinedFields := make(Fields, len(f)+len(fs)+len(l.permanentFields))
 
 	for k, v := range f {
-		if v == nil {
-			v = "nil"
+		combinedFields[k] = formatField(v)
+	}
+
+	// Only the errors of fs are needed from here on, to classify and
+	// detail them, so f holds those instead of every field of fs.
+	for _, fd := range fs {
+		v, err := fd.format()
+		combinedFields[fd.Key] = v
+
+		if err != nil {
+			if f == nil {
+				f = Fields{}
+			}
+			f[fd.Key] = err
+		} else if f != nil {
+			delete(f, fd.Key)
 		}
-		combinedFields[k] = fmt.Sprint(v)
 	}
 
+	addErrorClass(combinedFields, f, msg)
+
 	for k, v := range l.permanentFields {
-		if v == nil {
-			v = "nil"
-		}
-		combinedFields[k] = fmt.Sprint(v)
+		combinedFields[k] = formatField(v)
 	}
 
 	if msg == nil {
 		msg = "nil"
 	}
 
+	cfg := l.getConfig()
+
+	if (cfg.errorStacks || cfg.errorCauses) && hasError(f) {
+		var stack []string
+		if cfg.errorStacks {
+			stack = callerStack(l.callDepth + 1 + skip)
+		}
+		addErrorDetails(combinedFields, f, stack, cfg.errorCauses)
+	}
+
+	addDefaults(combinedFields, cfg.defaults)
+
+	message := formatValue(msg)
+	if l.stripANSI {
+		stripANSI(combinedFields)
+		message = ansiRe.ReplaceAllString(message, "")
+	}
+
+	ev := &Event{
+		Level:   lv,
+		File:    file,
+		Time:    l.now(),
+		Fields:  combinedFields,
+		Message: message,
+	}
+
+	if cfg.idGen != nil {
+		ev.ID = cfg.idGen.NewID()
+	}
+
+	if lv == PanicLevel {
+		ev.Metadata = panicMetadata(msg)
+	}
+
+	if l.name != "" {
+		if ev.Metadata == nil {
+			ev.Metadata = Fields{}
+		}
+		ev.Metadata[l.keyNames().Logger] = l.name
+	}
+
+	if function != "" {
+		if ev.Metadata == nil {
+			ev.Metadata = Fields{}
+		}
+		ev.Metadata[l.keyNames().Function] = function
+	}
+
+	if l.goroutineID {
+		if id := goroutineID(); id != "" {
+			if ev.Metadata == nil {
+				ev.Metadata = Fields{}
+			}
+			ev.Metadata[l.keyNames().Goroutine] = id
+		}
+	}
+
+	cfg.addSeverity(ev)
+
+	if cfg.cardinality != nil {
+		for _, a := range cfg.cardinality.guard(ev) {
+			l.write(a)
+		}
+	}
+
+	for _, h := range cfg.hooks {
+		h.Fire(ev)
+	}
+
+	if cfg.fingerprintKeys != nil {
+		ev.Fingerprint = fingerprint(ev.Message, ev.Fields, cfg.fingerprintKeys)
+	}
+
+	if a := cfg.aggregates[ev.Message]; a != nil {
+		if s := a.add(ev); s != nil {
+			l.write(s)
+		}
+		return ""
+	}
+
+	l.record(ev)
+	cfg.annotations.remember(ev)
+
+	es := l.write(ev)
+
+	if cfg.dupes != nil {
+		cfg.dupes.observe(l, ev)
+	}
+
+	return es
+}
+
+// write encodes ev and writes it out, returning the encoded event.
+func (l *Logger) write(ev *Event) string {
+	k := l.keyNames()
+
 	e := &event{
 		Metadata: Fields{
-			"level": string(lv),
-			"file":  l.fileInfo(),
-			"time":  time.Now().UTC().Format(time.RFC3339Nano),
+			k.Level: string(ev.Level),
+			k.Time:  ev.Time.Format(time.RFC3339Nano),
 		},
-		Fields:  combinedFields,
-		Message: fmt.Sprint(msg),
+		Fields:  ev.Fields,
+		Message: ev.Message,
+	}
+
+	if l.unixNano {
+		e.Metadata[k.Time] = ev.Time.UnixNano()
+	}
+
+	if ev.File != "" {
+		e.Metadata[k.File] = ev.File
+	}
+
+	if ev.ID != "" {
+		e.Metadata[k.EventID] = ev.ID
+	}
+
+	if ev.Fingerprint != "" {
+		e.Metadata[k.Fingerprint] = ev.Fingerprint
+	}
+
+	for k, v := range ev.Metadata {
+		if _, ok := e.Metadata[k]; !ok {
+			e.Metadata[k] = v
+		}
+	}
+
+	for k, v := range l.run {
+		if _, ok := e.Metadata[k]; !ok {
+			e.Metadata[k] = v
+		}
+	}
+
+	if l.seq != nil {
+		e.Metadata[k.Seq] = l.nextSeq()
 	}
 
-	byt, _ := json.Marshal(e)
+	byt := marshal(e, k, l.flat)
 	es := string(byt)
-	l.logger.Output(l.callDepth, es)
+	if !l.hold(es) {
+		l.output(es)
+	}
+
+	return es
+}
+
+// SetEventID makes the Logger stamp every event with a unique
+// "event_id" in its metadata, generated by g, so that events can be
+// deduplicated downstream and referenced from alerts and tickets.
+// g may be one of the generators of this package, such as UUIDv4,
+// UUIDv7, or ULID:
+//
+//	l.SetEventID(slog.ULID)
+//
+// If g is nil, events are not stamped, which is the default.
+func (l *Logger) SetEventID(g IDGenerator) {
+	l.mu.Lock()
+	old := l.cfg.idGen
+	l.cfg.idGen = g
+	l.mu.Unlock()
+
+	l.configChanged("event_id", describeType(old), describeType(g))
+}
 
-	if lv == panicLevel {
-		panic(es)
+func (l *Logger) getConfig() config {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.cfg
+}
+
+// FirstError returns a copy of the first event logged at the error
+// level, or a more severe level, since the Logger was created or since
+// ResetFirstError was last called. It returns nil if there is none.
+//
+// Like Summary, the first error is shared with the Loggers derived
+// with Named or With.
+//
+// It is useful for batch jobs that report what first went wrong
+// when they exit.
+func (l *Logger) FirstError() *Event {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+
+	if l.rec.firstErr == nil {
+		return nil
 	}
+
+	return l.rec.firstErr.Clone()
+}
+
+// ResetFirstError forgets the event returned by FirstError.
+func (l *Logger) ResetFirstError() {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+
+	l.rec.firstErr = nil
 }
 
-func (l *Logger) fileInfo() string {
-	_, file, line, ok := runtime.Caller(l.callDepth)
+func (l *Logger) record(e *Event) {
+	r := l.rec
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.counts[string(e.Level)]++
+
+	if _, ok := r.messages[e.Message]; ok || len(r.messages) < maxSummaryMessages {
+		r.messages[e.Message]++
+	}
+
+	if r.firstErr == nil && isErrorLevel(e.Level) {
+		r.firstErr = e
+	}
+}
+
+// now returns the current time in UTC from the Logger's clock.
+func (l *Logger) now() time.Time {
+	t := time.Now
+	if l.clock != nil {
+		t = l.clock
+	}
+
+	if l.localTime {
+		return t().Local()
+	}
+
+	return t().UTC()
+}
+
+// fileInfo returns the file name and line number of the caller and,
+// if the Logger logs it, the name of the calling function, skipping
+// skip more frames than the Logger's call depth.
+func (l *Logger) fileInfo(skip int) (string, string) {
+	pc, file, line, ok := runtime.Caller(l.callDepth + skip)
 	if !ok {
-		file = "?"
-		line = 0
-	} else {
+		return "?:0", ""
+	}
+
+	if !l.callerFullPath {
 		slash := strings.LastIndex(file, "/")
 		if slash >= 0 {
 			file = file[slash+1:]
 		}
 	}
 
-	return fmt.Sprintf("%s:%d", file, line)
+	var function string
+	if l.callerFunction {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			function = fn.Name()
+		}
+	}
+
+	return fmt.Sprintf("%s:%d", file, line), function
 }
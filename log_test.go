@@ -1,9 +1,11 @@
 package slog
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -25,7 +27,7 @@ func TestLog(t *testing.T) {
 	tests := []struct {
 		name    string
 		msg     string
-		lv      level
+		lv      Level
 		f       Fields
 		permF   Fields
 		expF    Fields
@@ -34,13 +36,13 @@ func TestLog(t *testing.T) {
 		{
 			name:    "trace",
 			msg:     "hello",
-			lv:      traceLevel,
+			lv:      TraceLevel,
 			expKeys: []string{"_metadata", "message"},
 		},
 		{
 			name:    "trace fields",
 			msg:     "hello",
-			lv:      traceLevel,
+			lv:      TraceLevel,
 			f:       Fields{"test": "message"},
 			expF:    Fields{"test": "message"},
 			expKeys: []string{"_metadata", "message", "fields"},
@@ -48,7 +50,30 @@ func TestLog(t *testing.T) {
 		{
 			name:    "trace permanent fields",
 			msg:     "hello",
-			lv:      traceLevel,
+			lv:      TraceLevel,
+			f:       Fields{"test": "shadow", "local": "message"},
+			permF:   Fields{"test": "message"},
+			expF:    Fields{"test": "message", "local": "message"},
+			expKeys: []string{"_metadata", "message", "fields"},
+		},
+		{
+			name:    "debug",
+			msg:     "hello",
+			lv:      DebugLevel,
+			expKeys: []string{"_metadata", "message"},
+		},
+		{
+			name:    "debug fields",
+			msg:     "hello",
+			lv:      DebugLevel,
+			f:       Fields{"test": "message"},
+			expF:    Fields{"test": "message"},
+			expKeys: []string{"_metadata", "message", "fields"},
+		},
+		{
+			name:    "debug permanent fields",
+			msg:     "hello",
+			lv:      DebugLevel,
 			f:       Fields{"test": "shadow", "local": "message"},
 			permF:   Fields{"test": "message"},
 			expF:    Fields{"test": "message", "local": "message"},
@@ -57,13 +82,13 @@ func TestLog(t *testing.T) {
 		{
 			name:    "info",
 			msg:     "hello",
-			lv:      infoLevel,
+			lv:      InfoLevel,
 			expKeys: []string{"_metadata", "message"},
 		},
 		{
 			name:    "info fields",
 			msg:     "hello",
-			lv:      infoLevel,
+			lv:      InfoLevel,
 			f:       Fields{"test": "message"},
 			expF:    Fields{"test": "message"},
 			expKeys: []string{"_metadata", "message", "fields"},
@@ -71,7 +96,7 @@ func TestLog(t *testing.T) {
 		{
 			name:    "info permanent fields",
 			msg:     "hello",
-			lv:      infoLevel,
+			lv:      InfoLevel,
 			f:       Fields{"test": "shadow", "local": "message"},
 			permF:   Fields{"test": "message"},
 			expF:    Fields{"test": "message", "local": "message"},
@@ -80,13 +105,13 @@ func TestLog(t *testing.T) {
 		{
 			name:    "warn",
 			msg:     "hello",
-			lv:      warnLevel,
+			lv:      WarnLevel,
 			expKeys: []string{"_metadata", "message"},
 		},
 		{
 			name:    "warn fields",
 			msg:     "hello",
-			lv:      warnLevel,
+			lv:      WarnLevel,
 			f:       Fields{"test": "message"},
 			expF:    Fields{"test": "message"},
 			expKeys: []string{"_metadata", "message", "fields"},
@@ -94,7 +119,7 @@ func TestLog(t *testing.T) {
 		{
 			name:    "warn permanent fields",
 			msg:     "hello",
-			lv:      warnLevel,
+			lv:      WarnLevel,
 			f:       Fields{"test": "shadow", "local": "message"},
 			permF:   Fields{"test": "message"},
 			expF:    Fields{"test": "message", "local": "message"},
@@ -103,13 +128,13 @@ func TestLog(t *testing.T) {
 		{
 			name:    "error",
 			msg:     "hello",
-			lv:      errorLevel,
+			lv:      ErrorLevel,
 			expKeys: []string{"_metadata", "message"},
 		},
 		{
 			name:    "error fields",
 			msg:     "hello",
-			lv:      errorLevel,
+			lv:      ErrorLevel,
 			f:       Fields{"test": "message"},
 			expF:    Fields{"test": "message"},
 			expKeys: []string{"_metadata", "message", "fields"},
@@ -117,7 +142,7 @@ func TestLog(t *testing.T) {
 		{
 			name:    "error permanent fields",
 			msg:     "hello",
-			lv:      errorLevel,
+			lv:      ErrorLevel,
 			f:       Fields{"test": "shadow", "local": "message"},
 			permF:   Fields{"test": "message"},
 			expF:    Fields{"test": "message", "local": "message"},
@@ -126,13 +151,13 @@ func TestLog(t *testing.T) {
 		{
 			name:    "panic",
 			msg:     "hello",
-			lv:      errorLevel,
+			lv:      ErrorLevel,
 			expKeys: []string{"_metadata", "message"},
 		},
 		{
 			name:    "panic fields",
 			msg:     "hello",
-			lv:      panicLevel,
+			lv:      PanicLevel,
 			f:       Fields{"test": "message"},
 			expF:    Fields{"test": "message"},
 			expKeys: []string{"_metadata", "message", "fields"},
@@ -140,7 +165,7 @@ func TestLog(t *testing.T) {
 		{
 			name:    "panic permanent fields",
 			msg:     "hello",
-			lv:      panicLevel,
+			lv:      PanicLevel,
 			f:       Fields{"test": "shadow", "local": "message"},
 			permF:   Fields{"test": "message"},
 			expF:    Fields{"test": "message", "local": "message"},
@@ -152,7 +177,7 @@ func TestLog(t *testing.T) {
 		var (
 			test = test
 			mw   = &mockWriter{}
-			l    = New(DefaultCallDepth, mw, test.permF)
+			l    = NewLogger(WithOutput(mw), WithFields(test.permF), WithClock(testClock))
 			fn   func(msg interface{})
 		)
 
@@ -237,15 +262,8 @@ func TestLog(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			gotTime, err := time.Parse(time.RFC3339, fmt.Sprint(e.Metadata["time"]))
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			gotTime = gotTime.Round(time.Minute)
-			expTime := time.Now().UTC().Round(time.Minute)
-			if !expTime.Equal(gotTime) {
-				t.Fatalf("expected '%s' time, got '%s'", expTime, gotTime)
+			if exp := "2021-01-01T00:00:00.000000001Z"; e.Metadata["time"] != exp {
+				t.Fatalf("expected '%s' time, got '%s'", exp, e.Metadata["time"])
 			}
 
 			if test.msg != e.Message {
@@ -279,7 +297,7 @@ func TestLog(t *testing.T) {
 func TestDefaultStdOut(t *testing.T) {
 	t.Parallel()
 
-	l := New(DefaultCallDepth, nil, nil)
+	l := NewLogger(WithOutput(nil))
 	w, ok := l.logger.Writer().(*os.File)
 	if !ok || w != os.Stdout {
 		t.Fatal(
@@ -314,7 +332,7 @@ func TestUnknownStack(t *testing.T) {
 func TestDefaultLogger(t *testing.T) {
 	t.Parallel()
 
-	expect := func(mw *mockWriter, lv level, f Fields) {
+	expect := func(mw *mockWriter, lv Level, f Fields) {
 		var e event
 		if err := json.Unmarshal(mw.byt, &e); err != nil {
 			t.Fatal(err)
@@ -355,33 +373,110 @@ func TestDefaultLogger(t *testing.T) {
 	defaultLogger.logger.SetOutput(mw)
 
 	Trace(msg)
-	expect(mw, traceLevel, nil)
+	expect(mw, TraceLevel, nil)
 
 	Tracef(fields, msg)
-	expect(mw, traceLevel, fields)
+	expect(mw, TraceLevel, fields)
+
+	Debug(msg)
+	expect(mw, DebugLevel, nil)
+
+	Debugf(fields, msg)
+	expect(mw, DebugLevel, fields)
 
 	Info(msg)
-	expect(mw, infoLevel, nil)
+	expect(mw, InfoLevel, nil)
 
 	Infof(fields, msg)
-	expect(mw, infoLevel, fields)
+	expect(mw, InfoLevel, fields)
 
 	Warn(msg)
-	expect(mw, warnLevel, nil)
+	expect(mw, WarnLevel, nil)
 
 	Warnf(fields, msg)
-	expect(mw, warnLevel, fields)
+	expect(mw, WarnLevel, fields)
 
 	Error(msg)
-	expect(mw, errorLevel, nil)
+	expect(mw, ErrorLevel, nil)
 
 	Errorf(fields, msg)
-	expect(mw, errorLevel, fields)
+	expect(mw, ErrorLevel, fields)
+
+	Log(WarnLevel, fields, msg)
+	expect(mw, WarnLevel, fields)
+
+	ctx := context.Background()
+
+	TraceCtx(ctx, msg)
+	expect(mw, TraceLevel, nil)
+
+	DebugCtx(ctx, msg)
+	expect(mw, DebugLevel, nil)
+
+	InfoCtx(ctx, msg)
+	expect(mw, InfoLevel, nil)
+
+	WarnCtx(ctx, msg)
+	expect(mw, WarnLevel, nil)
+
+	ErrorCtx(ctx, msg)
+	expect(mw, ErrorLevel, nil)
+
+	LogCtx(ctx, WarnLevel, fields, msg)
+	expect(mw, WarnLevel, fields)
+
+	Traces(msg, String("hello", "world"))
+	expect(mw, TraceLevel, fields)
+
+	Debugs(msg, String("hello", "world"))
+	expect(mw, DebugLevel, fields)
+
+	Infos(msg, String("hello", "world"))
+	expect(mw, InfoLevel, fields)
+
+	Warns(msg, String("hello", "world"))
+	expect(mw, WarnLevel, fields)
+
+	Errors(msg, String("hello", "world"))
+	expect(mw, ErrorLevel, fields)
+
+	LogDepth(0, WarnLevel, msg, String("hello", "world"))
+	expect(mw, WarnLevel, fields)
+
+	Tracew(msg, "hello", "world")
+	expect(mw, TraceLevel, fields)
+
+	Debugw(msg, "hello", "world")
+	expect(mw, DebugLevel, fields)
+
+	Infow(msg, "hello", "world")
+	expect(mw, InfoLevel, fields)
+
+	Warnw(msg, "hello", "world")
+	expect(mw, WarnLevel, fields)
+
+	Errorw(msg, "hello", "world")
+	expect(mw, ErrorLevel, fields)
+
+	Tracem("%s", msg)
+	expect(mw, TraceLevel, nil)
+
+	Debugm("%s", msg)
+	expect(mw, DebugLevel, nil)
+
+	Infom("%s", msg)
+	expect(mw, InfoLevel, nil)
+
+	Warnm("%s", msg)
+	expect(mw, WarnLevel, nil)
+
+	Errorm("%s", msg)
+	expect(mw, ErrorLevel, nil)
 
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				expect(mw, panicLevel, nil)
+				expect(mw, PanicLevel, nil)
 			}
 		}()
 		Panic(msg)
@@ -390,7 +485,7 @@ func TestDefaultLogger(t *testing.T) {
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				expect(mw, panicLevel, fields)
+				expect(mw, PanicLevel, fields)
 			}
 		}()
 		Panicf(fields, msg)
@@ -400,7 +495,7 @@ func TestDefaultLogger(t *testing.T) {
 func getLogFunc(
 	t *testing.T,
 	l *Logger,
-	lv level,
+	lv Level,
 	msg interface{},
 ) func(msg interface{}) {
 	t.Helper()
@@ -408,6 +503,8 @@ func getLogFunc(
 	switch lv {
 	case "trace":
 		return l.Trace
+	case "debug":
+		return l.Debug
 	case "info":
 		return l.Info
 	case "warn":
@@ -433,7 +530,7 @@ func getLogFunc(
 func getLogFuncf(
 	t *testing.T,
 	l *Logger,
-	lv level,
+	lv Level,
 	f Fields,
 	msg interface{},
 ) func(msg interface{}) {
@@ -444,6 +541,8 @@ func getLogFuncf(
 	switch lv {
 	case "trace":
 		fn = l.Tracef
+	case "debug":
+		fn = l.Debugf
 	case "info":
 		fn = l.Infof
 	case "warn":
@@ -468,3 +567,341 @@ func getLogFuncf(
 		fn(f, msg)
 	}
 }
+
+func TestFirstError(t *testing.T) {
+	t.Parallel()
+
+	l := NewLogger(WithOutput(&mockWriter{}))
+	if e := l.FirstError(); e != nil {
+		t.Fatalf("expected no first error, got '%v'", e)
+	}
+
+	l.Info("hello")
+	l.Errorf(Fields{"attempt": 1}, "first")
+	l.Error("second")
+
+	e := l.FirstError()
+	if e == nil {
+		t.Fatal("expected a first error, got nil")
+	}
+
+	if e.Message != "first" {
+		t.Fatalf("expected message '%s', got '%s'", "first", e.Message)
+	}
+
+	if e.Level != ErrorLevel {
+		t.Fatalf("expected level '%s', got '%s'", ErrorLevel, e.Level)
+	}
+
+	if e.Fields["attempt"] != "1" {
+		t.Fatalf("expected field '%s', got '%s'", "1", e.Fields["attempt"])
+	}
+
+	if e.Time.IsZero() {
+		t.Fatal("expected time to be set, but it was not")
+	}
+
+	l.ResetFirstError()
+	if e := l.FirstError(); e != nil {
+		t.Fatalf("expected no first error after reset, got '%v'", e)
+	}
+
+	l.Error("third")
+	if e := l.FirstError(); e == nil || e.Message != "third" {
+		t.Fatalf("expected message '%s', got '%v'", "third", e)
+	}
+}
+
+func TestEventID(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mw = &mockWriter{}
+		l  = NewLogger(WithOutput(mw))
+		n  = 0
+	)
+
+	l.Info("hello")
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := e.Metadata["event_id"]; ok {
+		t.Fatal("expected no event_id by default, but it existed")
+	}
+
+	l.SetEventID(IDGeneratorFunc(func() string {
+		n++
+		return strconv.Itoa(n)
+	}))
+
+	for _, exp := range []string{"1", "2"} {
+		l.Info("hello")
+
+		var e event
+		if err := json.Unmarshal(mw.byt, &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Metadata["event_id"] != exp {
+			t.Fatalf("expected event_id '%s', got '%v'", exp, e.Metadata["event_id"])
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		opts   []Option
+		lv     Level
+		logged bool
+		fields int
+		file   bool
+	}{
+		{name: "defaults", lv: TraceLevel, logged: true, file: true},
+		{name: "fields", opts: []Option{WithFields(Fields{"a": "b"})}, lv: InfoLevel, logged: true, fields: 1, file: true},
+		{name: "level below", opts: []Option{WithLevel(WarnLevel)}, lv: InfoLevel},
+		{name: "level at", opts: []Option{WithLevel(WarnLevel)}, lv: WarnLevel, logged: true, file: true},
+		{name: "no caller", opts: []Option{WithCaller(false)}, lv: InfoLevel, logged: true},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			l := NewLogger(append([]Option{WithOutput(mw)}, test.opts...)...)
+			l.Log(test.lv, nil, "hello")
+
+			if logged := mw.byt != nil; logged != test.logged {
+				t.Fatalf("expected logged to be '%t', got '%t'", test.logged, logged)
+			}
+
+			if !test.logged {
+				return
+			}
+
+			var e event
+			if err := json.Unmarshal(mw.byt, &e); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(e.Fields) != test.fields {
+				t.Fatalf("expected '%d' field(s), got '%d'", test.fields, len(e.Fields))
+			}
+
+			file, ok := e.Metadata["file"].(string)
+			if ok != test.file {
+				t.Fatalf("expected file to be logged to be '%t', got '%t'", test.file, ok)
+			}
+
+			if ok && !strings.HasPrefix(file, "log_test.go:") {
+				t.Fatalf("expected file '%s', got '%s'", "log_test.go", file)
+			}
+		})
+	}
+
+	if l := NewLogger(WithOutput(nil)); l.logger.Writer() != os.Stdout {
+		t.Fatal("expected a nil output to default to os.Stdout, but it did not")
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	New(DefaultCallDepth, mw, Fields{"a": "b"}).Info("hello")
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Fields["a"] != "b" {
+		t.Fatalf("expected field 'a' to be '%s', got '%v'", "b", e.Fields["a"])
+	}
+
+	if file, _ := e.Metadata["file"].(string); !strings.HasPrefix(file, "log_test.go:") {
+		t.Fatalf("expected file '%s', got '%s'", "log_test.go", file)
+	}
+
+	if l := New(DefaultCallDepth, nil, nil); l.logger.Writer() != os.Stdout {
+		t.Fatal("expected a nil output to default to os.Stdout, but it did not")
+	}
+}
+
+// testClock returns a fixed time, so that tests can compare
+// times exactly.
+func testClock() time.Time {
+	return time.Date(2021, 1, 1, 0, 0, 0, 1, time.UTC)
+}
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+
+	tests := []struct {
+		name string
+		opts []Option
+		exp  string
+	}{
+		{name: "utc", exp: "2021-01-01T00:00:00.000000001Z"},
+		{name: "local", opts: []Option{WithLocalTime()}, exp: testClock().Local().Format(time.RFC3339Nano)},
+		{
+			name: "clock location",
+			opts: []Option{WithClock(func() time.Time { return testClock().In(loc) })},
+			exp:  "2021-01-01T00:00:00.000000001Z",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			opts := append([]Option{WithOutput(mw), WithClock(testClock)}, test.opts...)
+			NewLogger(opts...).With(Fields{}).Info("hello")
+
+			var e event
+			if err := json.Unmarshal(mw.byt, &e); err != nil {
+				t.Fatal(err)
+			}
+
+			if e.Metadata["time"] != test.exp {
+				t.Fatalf("expected time '%s', got '%s'", test.exp, e.Metadata["time"])
+			}
+		})
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	t.Parallel()
+
+	run := func() []string {
+		var (
+			w     = &linesWriter{}
+			tick  int64
+			clock = func() time.Time {
+				tick++
+				return time.Unix(1600000000, tick)
+			}
+			l = NewLogger(WithOutput(w), WithDeterministic(clock))
+		)
+
+		for i := 0; i < 10; i++ {
+			l.Infof(Fields{"i": i, "b": "x", "a": []int{i}}, "apply")
+		}
+		l.Named("fsm").Warn("done")
+
+		return w.lines
+	}
+
+	a, b := run(), run()
+
+	if len(a) != 11 || len(a) != len(b) {
+		t.Fatalf("expected '%d' line(s) per run, got '%d' and '%d'", 11, len(a), len(b))
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("expected identical lines, got '%s' and '%s'", a[i], b[i])
+		}
+	}
+
+	exp := `{"_metadata":{"event_id":"1","level":"info","time":"2020-09-13T12:26:40.000000001Z"},` +
+		`"fields":{"a":"[0]","b":"x","i":"0"},"message":"apply"}`
+	if a[0] != exp {
+		t.Fatalf("expected line '%s', got '%s'", exp, a[0])
+	}
+}
+
+func TestGroups(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw), WithFields(Fields{"service": Fields{"name": "api"}}))
+
+	l.Infof(Fields{
+		"http": Fields{
+			"method": "GET",
+			"status": 200,
+			"header": map[string]interface{}{"Content-Type": "text/plain"},
+		},
+		"empty": Fields{},
+	}, "request")
+
+	exp := `"fields":{"empty":{},"http":{"header":{"Content-Type":"text/plain"},` +
+		`"method":"GET","status":"200"},"service":{"name":"api"}}`
+	if !strings.Contains(string(mw.byt), exp) {
+		t.Fatalf("expected '%s' in '%s'", exp, mw.byt)
+	}
+}
+
+func TestUnixNanoTime(t *testing.T) {
+	t.Parallel()
+
+	clock := func() time.Time { return time.Unix(1600000000, 1) }
+
+	mw := &mockWriter{}
+	NewLogger(WithOutput(mw), WithDeterministic(clock), WithUnixNanoTime()).With(Fields{}).Info("hello")
+
+	exp := `{"_metadata":{"event_id":"1","level":"info","time":1600000000000000001},"message":"hello"}`
+	if got := strings.TrimSpace(string(mw.byt)); got != exp {
+		t.Fatalf("expected '%s', got '%s'", exp, got)
+	}
+}
+
+func TestCallerInfo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		file     *regexp.Regexp
+		function string
+	}{
+		{name: "default", file: regexp.MustCompile(`^log_test\.go:\d+$`)},
+		{name: "full path", opts: []Option{WithCallerFullPath()}, file: regexp.MustCompile(`^/.+/log_test\.go:\d+$`)},
+		{
+			name:     "function",
+			opts:     []Option{WithCallerFunction()},
+			file:     regexp.MustCompile(`^log_test\.go:\d+$`),
+			function: "github.com/safe-waters/slog.TestCallerInfo.func1",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			NewLogger(append([]Option{WithOutput(mw)}, test.opts...)...).With(Fields{}).Info("hello")
+
+			var e struct {
+				Metadata map[string]string `json:"_metadata"`
+			}
+			if err := json.Unmarshal(mw.byt, &e); err != nil {
+				t.Fatal(err)
+			}
+
+			if !test.file.MatchString(e.Metadata["file"]) {
+				t.Fatalf("expected file to match '%s', got '%s'", test.file, e.Metadata["file"])
+			}
+
+			if e.Metadata["function"] != test.function {
+				t.Fatalf("expected function '%s', got '%s'", test.function, e.Metadata["function"])
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slog
+
+import "encoding/json"
+
+func marshalEvent(e *event) []byte {
+	byt, _ := json.Marshal(e)
+	return byt
+}
+
+func marshalFields(f Fields) []byte {
+	byt, _ := json.Marshal(f)
+	return byt
+}
+
+func marshalReport(r Report) ([]byte, error) {
+	return json.Marshal(struct {
+		Report
+		Duration string `json:"duration"`
+	}{
+		Report:   r,
+		Duration: r.Duration.String(),
+	})
+}
@@ -0,0 +1,20 @@
+//go:build tinygo
+// +build tinygo
+
+package slog
+
+// marshalEvent encodes e without encoding/json, whose reflection
+// is unsupported or expensive on TinyGo.
+func marshalEvent(e *event) []byte {
+	return appendEvent(make([]byte, 0, 256), e)
+}
+
+// marshalFields encodes f without encoding/json.
+func marshalFields(f Fields) []byte {
+	return appendJSON(make([]byte, 0, 256), f)
+}
+
+// marshalReport encodes r without encoding/json.
+func marshalReport(r Report) ([]byte, error) {
+	return appendReport(make([]byte, 0, 256), r), nil
+}
@@ -0,0 +1,59 @@
+//go:build android
+// +build android
+
+package mobile
+
+/*
+#cgo LDFLAGS: -llog
+#include <android/log.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/safe-waters/slog"
+)
+
+// LogcatSink is a Sink that writes to Android's logcat under Tag.
+type LogcatSink struct {
+	Tag string
+}
+
+// NewLogcatSink returns a LogcatSink that writes under tag.
+func NewLogcatSink(tag string) *LogcatSink {
+	return &LogcatSink{Tag: tag}
+}
+
+// Write writes line to logcat with the priority that matches level.
+func (s *LogcatSink) Write(level string, line string) {
+	tag := C.CString(s.Tag)
+	defer C.free(unsafe.Pointer(tag))
+
+	text := C.CString(line)
+	defer C.free(unsafe.Pointer(text))
+
+	C.__android_log_write(logcatPriority(level), tag, text)
+}
+
+// logcatPriorities maps levels to logcat priorities. Custom levels
+// map like the closest less severe built-in level.
+var logcatPriorities = slog.SeverityTable{
+	slog.TraceLevel: C.int(C.ANDROID_LOG_VERBOSE),
+	slog.DebugLevel: C.int(C.ANDROID_LOG_DEBUG),
+	slog.InfoLevel:  C.int(C.ANDROID_LOG_INFO),
+	slog.WarnLevel:  C.int(C.ANDROID_LOG_WARN),
+	slog.ErrorLevel: C.int(C.ANDROID_LOG_ERROR),
+	slog.PanicLevel: C.int(C.ANDROID_LOG_FATAL),
+	slog.FatalLevel: C.int(C.ANDROID_LOG_FATAL),
+}
+
+func logcatPriority(level string) C.int {
+	p, ok := logcatPriorities.Severity(slog.Level(level))
+	if !ok {
+		return C.int(C.ANDROID_LOG_INFO)
+	}
+
+	return p.(C.int)
+}
@@ -0,0 +1,112 @@
+// Package mobile provides bindings to slog that are compatible with
+// gomobile bind, so that mobile apps that embed Go share one logging
+// pipeline with their Go code.
+//
+// Levels are passed as strings, such as "info", and fields are built
+// with Fields, because gomobile cannot bind slog's level type or maps.
+package mobile
+
+import (
+	"fmt"
+
+	"github.com/safe-waters/slog"
+)
+
+// Sink receives encoded events along with their level. Apps implement
+// it in Java, Kotlin, Objective-C, or Swift to forward events to the
+// platform's log, or use the built-in LogcatSink on Android and
+// OSLogSink on iOS.
+type Sink interface {
+	Write(level string, line string)
+}
+
+// Fields holds key-value pairs for logs.
+type Fields struct {
+	f slog.Fields
+}
+
+// NewFields returns empty Fields.
+func NewFields() *Fields {
+	return &Fields{f: slog.Fields{}}
+}
+
+// Put sets key to value and returns the Fields, so that calls can be
+// chained.
+func (f *Fields) Put(key, value string) *Fields {
+	f.f[key] = value
+	return f
+}
+
+// Logger logs events to a Sink.
+type Logger struct {
+	// levels holds a Logger per level, each writing to the Sink with
+	// its level, so that the Sink does not decode the level from events.
+	levels map[string]*slog.Logger
+}
+
+// NewLogger returns a Logger that writes to sink.
+func NewLogger(sink Sink) *Logger {
+	l := &Logger{levels: map[string]*slog.Logger{}}
+
+	for _, lv := range []slog.Level{
+		slog.TraceLevel,
+		slog.DebugLevel,
+		slog.InfoLevel,
+		slog.WarnLevel,
+		slog.ErrorLevel,
+	} {
+		w := &sinkWriter{sink: sink, level: string(lv)}
+
+		// Log and LogFields each call log, which calls the level's
+		// Logger, so both skip two frames to report their callers.
+		l.levels[string(lv)] = slog.New(slog.DefaultCallDepth, w, nil).WithCallerSkip(2)
+	}
+
+	return l
+}
+
+// Log logs msg at level, which is one of "trace", "debug", "info",
+// "warn", or "error".
+func (l *Logger) Log(level string, msg string) error {
+	return l.log(level, nil, msg)
+}
+
+// LogFields logs fields and msg at level, which is one of "trace",
+// "debug", "info", "warn", or "error". f may be nil.
+func (l *Logger) LogFields(level string, f *Fields, msg string) error {
+	return l.log(level, f, msg)
+}
+
+func (l *Logger) log(level string, f *Fields, msg string) error {
+	sl, ok := l.levels[level]
+	if !ok {
+		return fmt.Errorf("mobile: unknown level %q", level)
+	}
+
+	var sf slog.Fields
+	if f != nil {
+		sf = f.f
+	}
+
+	sl.Log(slog.Level(level), sf, msg)
+
+	return nil
+}
+
+// sinkWriter adapts a Sink to an io.Writer
+// that writes events at level.
+type sinkWriter struct {
+	sink  Sink
+	level string
+}
+
+func (w *sinkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if n > 0 && p[n-1] == '\n' {
+		p = p[:n-1]
+	}
+
+	w.sink.Write(w.level, string(p))
+
+	return n, nil
+}
@@ -0,0 +1,82 @@
+package mobile
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type mockSink struct {
+	levels []string
+	lines  []string
+}
+
+func (s *mockSink) Write(level string, line string) {
+	s.levels = append(s.levels, level)
+	s.lines = append(s.lines, line)
+}
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	s := &mockSink{}
+	l := NewLogger(s)
+
+	if err := l.Log("info", "hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.LogFields("warn", NewFields().Put("k", "v"), "careful"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Log("loud", "hello"); err == nil {
+		t.Fatal("expected an error for an unknown level, got nil")
+	}
+
+	expLevels := []string{"info", "warn"}
+	if len(s.levels) != len(expLevels) {
+		t.Fatalf("expected '%d' event(s), got '%d'", len(expLevels), len(s.levels))
+	}
+
+	for i, lv := range expLevels {
+		if s.levels[i] != lv {
+			t.Fatalf("expected level '%s', got '%s'", lv, s.levels[i])
+		}
+	}
+
+	var e struct {
+		Fields  map[string]string `json:"fields"`
+		Message string            `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(s.lines[1]), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Fields["k"] != "v" || e.Message != "careful" {
+		t.Fatalf("expected field 'v' and message 'careful', got '%v'", e)
+	}
+}
+
+func TestLoggerCaller(t *testing.T) {
+	t.Parallel()
+
+	s := &mockSink{}
+	l := NewLogger(s)
+
+	l.Log("info", "hello")
+	l.LogFields("info", nil, "hello")
+
+	for _, line := range s.lines {
+		var e struct {
+			Metadata map[string]interface{} `json:"_metadata"`
+		}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if file, _ := e.Metadata["file"].(string); !strings.HasPrefix(file, "mobile_test.go:") {
+			t.Fatalf("expected the caller's file, got '%v'", e.Metadata["file"])
+		}
+	}
+}
@@ -0,0 +1,57 @@
+//go:build ios
+// +build ios
+
+package mobile
+
+/*
+#include <os/log.h>
+#include <stdlib.h>
+
+static void slog_os_log(os_log_type_t type, const char *line) {
+	os_log_with_type(OS_LOG_DEFAULT, type, "%{public}s", line);
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/safe-waters/slog"
+)
+
+// OSLogSink is a Sink that writes to Apple's unified logging system.
+type OSLogSink struct{}
+
+// NewOSLogSink returns an OSLogSink.
+func NewOSLogSink() *OSLogSink {
+	return &OSLogSink{}
+}
+
+// Write writes line to os_log with the type that matches level.
+func (s *OSLogSink) Write(level string, line string) {
+	text := C.CString(line)
+	defer C.free(unsafe.Pointer(text))
+
+	C.slog_os_log(osLogType(level), text)
+}
+
+// osLogTypes maps levels to os_log types. Custom levels map like
+// the closest less severe built-in level.
+var osLogTypes = slog.SeverityTable{
+	slog.TraceLevel: C.os_log_type_t(C.OS_LOG_TYPE_DEBUG),
+	slog.DebugLevel: C.os_log_type_t(C.OS_LOG_TYPE_DEBUG),
+	slog.InfoLevel:  C.os_log_type_t(C.OS_LOG_TYPE_INFO),
+	slog.WarnLevel:  C.os_log_type_t(C.OS_LOG_TYPE_DEFAULT),
+	slog.ErrorLevel: C.os_log_type_t(C.OS_LOG_TYPE_ERROR),
+	slog.PanicLevel: C.os_log_type_t(C.OS_LOG_TYPE_FAULT),
+	slog.FatalLevel: C.os_log_type_t(C.OS_LOG_TYPE_FAULT),
+}
+
+func osLogType(level string) C.os_log_type_t {
+	t, ok := osLogTypes.Severity(slog.Level(level))
+	if !ok {
+		return C.os_log_type_t(C.OS_LOG_TYPE_DEFAULT)
+	}
+
+	return t.(C.os_log_type_t)
+}
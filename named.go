@@ -0,0 +1,159 @@
+package slog
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	namedLevelsMu sync.RWMutex
+	namedLevels   = map[string]Level{}
+)
+
+// Named returns a child of the Logger named name, which tags its events
+// with a "logger" in their metadata. Names form a dotted hierarchy:
+// calling Named("pool") on a Logger named "db" returns one named
+// "db.pool". The level of each name can be set independently
+// with SetNamedLevel.
+//
+// The child writes to the same output as the Logger and starts with
+// a copy of its permanent fields and settings. Later changes to the
+// settings of one do not affect the other.
+// Events logged with the child count toward the Logger's FirstError
+// and Summary, and Pause and Resume apply to both.
+func (l *Logger) Named(name string) *Logger {
+	c := l.child()
+
+	if c.name == "" {
+		c.name = name
+	} else if name != "" {
+		c.name += "." + name
+	}
+
+	return c
+}
+
+// With returns a child of the Logger that adds f to its permanent
+// fields, so that fields such as a request ID can be bound once instead
+// of being passed on every call. Keys in f replace the Logger's
+// permanent fields of the same name.
+//
+// Like Named, the child writes to the same output and starts with
+// a copy of the Logger's settings.
+func (l *Logger) With(f Fields) *Logger {
+	c := l.child()
+
+	c.permanentFields = make(Fields, len(l.permanentFields)+len(f))
+	for k, v := range l.permanentFields {
+		c.permanentFields[k] = v
+	}
+	for k, v := range f {
+		c.permanentFields[k] = v
+	}
+
+	return c
+}
+
+// WithCallerSkip returns a child of the Logger that skips n more stack
+// frames to find the caller, so that packages that wrap a Logger in
+// their own helpers log the file and line number of their callers
+// rather than their own. For example, a helper that calls Info
+// directly skips one frame:
+//
+//	var l = slog.NewLogger().WithCallerSkip(1)
+//
+//	func Info(msg string) { l.Info(msg) }
+//
+// Like Named, the child writes to the same output and starts with
+// a copy of the Logger's settings.
+func (l *Logger) WithCallerSkip(n int) *Logger {
+	c := l.child()
+	c.callDepth += n
+
+	return c
+}
+
+// child returns a copy of the Logger that shares its output.
+func (l *Logger) child() *Logger {
+	cfg := l.getConfig()
+	cfg.hooks = append([]Hook(nil), cfg.hooks...)
+
+	return &Logger{
+		callDepth:       l.callDepth,
+		logger:          l.logger,
+		permanentFields: l.permanentFields,
+		name:            l.name,
+		noCaller:        l.noCaller,
+		callerFullPath:  l.callerFullPath,
+		callerFunction:  l.callerFunction,
+		goroutineID:     l.goroutineID,
+		clock:           l.clock,
+		localTime:       l.localTime,
+		strict:          l.strict,
+		keys:            l.keys,
+		flat:            l.flat,
+		stripANSI:       l.stripANSI,
+		unixNano:        l.unixNano,
+		seq:             l.seq,
+		targets:         l.targets,
+		run:             l.run,
+		lc:              &lifecycle{parent: l.lc},
+		fallback:        l.fallback,
+		cfg:             cfg,
+		rec:             l.rec,
+	}
+}
+
+// SetNamedLevel sets the minimum level of the Loggers named name,
+// and of their descendants that have no level of their own. For example,
+// after SetNamedLevel("db", WarnLevel), Loggers named "db" and "db.pool"
+// only log warnings and more severe events. A level set with
+// SetNamedLevel takes priority over those set with SetLevel
+// or SetAtomicLevel, and applies from the next log call.
+//
+// If lv is empty, the level of name is removed.
+func SetNamedLevel(name string, lv Level) {
+	namedLevelsMu.Lock()
+	old := namedLevels[name]
+	if lv == "" {
+		delete(namedLevels, name)
+	} else {
+		namedLevels[name] = lv
+	}
+	namedLevelsMu.Unlock()
+
+	notifyChange(nil, "named_level", describeNamedLevel(name, old), describeNamedLevel(name, lv))
+}
+
+// describeNamedLevel returns name and lv as "name=lv",
+// or "name=none" if lv is empty.
+func describeNamedLevel(name string, lv Level) string {
+	if lv == "" {
+		return name + "=none"
+	}
+
+	return name + "=" + string(lv)
+}
+
+// namedLevel returns the level set for name or,
+// failing that, for its closest ancestor.
+func namedLevel(name string) (Level, bool) {
+	namedLevelsMu.RLock()
+	defer namedLevelsMu.RUnlock()
+
+	if len(namedLevels) == 0 {
+		return "", false
+	}
+
+	for {
+		if lv, ok := namedLevels[name]; ok {
+			return lv, true
+		}
+
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			return "", false
+		}
+		name = name[:i]
+	}
+}
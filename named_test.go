@@ -0,0 +1,199 @@
+package slog
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+var namedTestN int64
+
+func TestNamed(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// The root name is unique across test runs,
+		// because named levels are global.
+		root = fmt.Sprintf("named-test-%d", atomic.AddInt64(&namedTestN, 1))
+		w    = &linesWriter{}
+		l    = NewLogger(WithOutput(w), WithFields(Fields{"service": "api"}))
+		db   = l.Named(root).Named("db")
+		pool = db.Named("pool")
+	)
+
+	SetNamedLevel(root+".db", WarnLevel)
+
+	tests := []struct {
+		l      *Logger
+		lv     Level
+		name   string
+		logged bool
+	}{
+		{l: l, lv: InfoLevel, logged: true},
+		{l: db, lv: InfoLevel, name: root + ".db", logged: false},
+		{l: db, lv: WarnLevel, name: root + ".db", logged: true},
+		{l: pool, lv: InfoLevel, name: root + ".db.pool", logged: false},
+		{l: pool, lv: ErrorLevel, name: root + ".db.pool", logged: true},
+	}
+
+	for _, test := range tests {
+		n := len(w.lines)
+		test.l.Log(test.lv, nil, "hello")
+
+		if logged := len(w.lines) > n; logged != test.logged {
+			t.Fatalf("expected logged to be '%t' for '%s' at '%s', got '%t'", test.logged, test.name, test.lv, logged)
+		}
+
+		if !test.logged {
+			continue
+		}
+
+		var e event
+		if err := json.Unmarshal([]byte(w.lines[n]), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if name, _ := e.Metadata["logger"].(string); name != test.name {
+			t.Fatalf("expected logger '%s', got '%s'", test.name, name)
+		}
+
+		if e.Fields["service"] != "api" {
+			t.Fatalf("expected field '%s', got '%v'", "api", e.Fields["service"])
+		}
+	}
+
+	SetNamedLevel(root+".db.pool", InfoLevel)
+	pool.Info("hello")
+
+	if len(w.lines) != 4 {
+		t.Fatalf("expected the closer name's level to apply, got '%d' line(s)", len(w.lines))
+	}
+
+	SetNamedLevel(root+".db", "")
+	db.Info("hello")
+
+	if len(w.lines) != 5 {
+		t.Fatalf("expected the removed level to no longer apply, got '%d' line(s)", len(w.lines))
+	}
+}
+
+func TestWith(t *testing.T) {
+	t.Parallel()
+
+	var (
+		w     = &linesWriter{}
+		l     = NewLogger(WithOutput(w), WithFields(Fields{"service": "api", "region": "eu"}))
+		req   = l.With(Fields{"request_id": "1", "region": "us"})
+		inner = req.With(Fields{"step": "auth"})
+	)
+
+	l.Info("parent")
+	req.Infof(Fields{"request_id": "2"}, "request")
+	inner.Info("inner")
+
+	exp := []Fields{
+		{"service": "api", "region": "eu"},
+		{"service": "api", "region": "us", "request_id": "1"},
+		{"service": "api", "region": "us", "request_id": "1", "step": "auth"},
+	}
+
+	if len(w.lines) != len(exp) {
+		t.Fatalf("expected '%d' line(s), got '%d'", len(exp), len(w.lines))
+	}
+
+	for i, line := range w.lines {
+		var e event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(e.Fields) != len(exp[i]) {
+			t.Fatalf("expected fields '%v', got '%v'", exp[i], e.Fields)
+		}
+
+		for k, v := range exp[i] {
+			if e.Fields[k] != v {
+				t.Fatalf("expected field '%s' to be '%v', got '%v'", k, v, e.Fields[k])
+			}
+		}
+
+		if !strings.HasPrefix(e.Metadata["file"].(string), "named_test.go:") {
+			t.Fatalf("expected file '%s', got '%s'", "named_test.go", e.Metadata["file"])
+		}
+	}
+}
+
+// callerSkipTestHelper wraps a Logger, like a helper package would.
+func callerSkipTestHelper(l *Logger, msg string) {
+	l.Info(msg)
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw))
+
+	_, _, line, _ := runtime.Caller(0)
+	callerSkipTestHelper(l.WithCallerSkip(1), "skipped")
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := fmt.Sprintf("named_test.go:%d", line+1); e.Metadata["file"] != exp {
+		t.Fatalf("expected file '%s', got '%s'", exp, e.Metadata["file"])
+	}
+
+	skipped := e.Metadata["file"]
+	callerSkipTestHelper(l, "not skipped")
+
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Metadata["file"] == skipped {
+		t.Fatalf("expected the file of the helper, got '%s'", e.Metadata["file"])
+	}
+}
+
+func TestChildSharesRecorder(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w))
+
+	l.With(Fields{"a": "1"}).Error("boom")
+	l.Named("db").Warn("slow")
+	l.WithCallerSkip(1).Info("hello")
+
+	if e := l.FirstError(); e == nil || e.Message != "boom" {
+		t.Fatalf("expected the first error 'boom', got '%v'", e)
+	}
+
+	r := l.Summary()
+	for _, lv := range []string{"error", "warn", "info"} {
+		if r.Counts[lv] != 1 {
+			t.Fatalf("expected '%d' %s event(s), got '%v'", 1, lv, r.Counts)
+		}
+	}
+
+	l.Pause()
+	l.With(nil).Info("held")
+
+	if len(w.lines) != 3 {
+		t.Fatalf("expected '%d' line(s) while paused, got '%d'", 3, len(w.lines))
+	}
+
+	if dropped := l.Named("db").Resume(); dropped != 0 {
+		t.Fatalf("expected '%d' dropped event(s), got '%d'", 0, dropped)
+	}
+
+	if len(w.lines) != 4 {
+		t.Fatalf("expected '%d' line(s) after Resume, got '%d'", 4, len(w.lines))
+	}
+}
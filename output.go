@@ -0,0 +1,87 @@
+package slog
+
+import (
+	"fmt"
+	"io"
+)
+
+// maxPaused is the number of events a paused Logger holds
+// before it starts dropping them.
+const maxPaused = 1000
+
+// SetOutput atomically sets where the Logger writes events.
+// If w is nil, events are discarded.
+//
+// Setting the output of a Logger also sets that of the Logger it was
+// derived from and of the Loggers derived from either with Named or
+// With, since they share its output, so that a rotated log file is
+// used by all of them.
+func (l *Logger) SetOutput(w io.Writer) {
+	if w == nil {
+		w = io.Discard
+	}
+
+	old := l.logger.Writer()
+	l.logger.SetOutput(w)
+
+	l.configChanged("output", fmt.Sprintf("%T", old), fmt.Sprintf("%T", w))
+}
+
+// Pause makes the Logger hold events in memory, instead of writing them,
+// until Resume is called. It is useful while the output is reconfigured,
+// for example while a log file is rotated.
+//
+// Pausing a Logger also pauses the Logger it was derived from and the
+// Loggers derived from either with Named or With, since they share
+// its output.
+//
+// At most 1000 events are held; further events are dropped.
+// Calling Pause on a paused Logger has no effect.
+func (l *Logger) Pause() {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+
+	if l.rec.paused == nil {
+		l.rec.paused = make([]string, 0, 16)
+	}
+}
+
+// Resume writes out the events held since Pause was called, in order,
+// and makes the Logger write events as usual again. It returns the
+// number of events that were dropped while the Logger was paused.
+//
+// Calling Resume on a Logger that is not paused has no effect.
+func (l *Logger) Resume() int {
+	r := l.rec
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, es := range r.paused {
+		l.output(es)
+	}
+
+	dropped := r.dropped
+	r.paused = nil
+	r.dropped = 0
+
+	return dropped
+}
+
+// hold holds the encoded event es and returns true if the Logger is paused.
+func (l *Logger) hold(es string) bool {
+	r := l.rec
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.paused == nil {
+		return false
+	}
+
+	if len(r.paused) < maxPaused {
+		r.paused = append(r.paused, es)
+	} else {
+		r.dropped++
+	}
+
+	return true
+}
@@ -0,0 +1,115 @@
+package slog
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestSetOutput(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a = &linesWriter{}
+		b = &linesWriter{}
+		l = NewLogger(WithOutput(a))
+	)
+
+	l.Info("a")
+	l.SetOutput(b)
+	l.Info("b")
+
+	if len(a.lines) != 1 || len(b.lines) != 1 {
+		t.Fatalf("expected one line per writer, got '%d' and '%d'", len(a.lines), len(b.lines))
+	}
+
+	l.SetOutput(nil)
+	if l.logger.Writer() != io.Discard {
+		t.Fatal("expected a nil output to discard, but it did not")
+	}
+}
+
+func TestSetOutputShared(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a     = &linesWriter{}
+		b     = &linesWriter{}
+		l     = NewLogger(WithOutput(a))
+		child = l.Named("child").With(Fields{"k": "v"})
+	)
+
+	child.SetOutput(b)
+	l.Info("parent")
+	child.Info("child")
+
+	if len(a.lines) != 0 || len(b.lines) != 2 {
+		t.Fatalf("expected both Loggers to write to the new output, got '%d' and '%d' line(s)", len(a.lines), len(b.lines))
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w))
+
+	l.Pause()
+	for i := 0; i < maxPaused+5; i++ {
+		l.Infof(Fields{"i": i}, "held")
+	}
+
+	if len(w.lines) != 0 {
+		t.Fatalf("expected no lines while paused, got '%d'", len(w.lines))
+	}
+
+	if dropped := l.Resume(); dropped != 5 {
+		t.Fatalf("expected '%d' dropped event(s), got '%d'", 5, dropped)
+	}
+
+	if len(w.lines) != maxPaused {
+		t.Fatalf("expected '%d' line(s), got '%d'", maxPaused, len(w.lines))
+	}
+
+	var e event
+	if err := json.Unmarshal([]byte(w.lines[0]), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Fields["i"] != "0" {
+		t.Fatalf("expected the first held event first, got '%s'", e.Fields["i"])
+	}
+
+	l.Info("after")
+	if len(w.lines) != maxPaused+1 {
+		t.Fatalf("expected '%d' line(s), got '%d'", maxPaused+1, len(w.lines))
+	}
+}
+
+func TestPauseConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		l  = NewLogger(WithOutput(io.Discard))
+		wg sync.WaitGroup
+	)
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Info("hello")
+			}
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		l.Pause()
+		l.SetOutput(io.Discard)
+		l.Resume()
+	}
+
+	wg.Wait()
+}
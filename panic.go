@@ -0,0 +1,81 @@
+package slog
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PanicError is the value that Panic and Panicf panic with.
+type PanicError struct {
+	// Value is the original message passed to Panic or Panicf.
+	Value interface{}
+	// Event is the encoded event that was logged.
+	Event string
+}
+
+// Error returns Value formatted as a string.
+func (p *PanicError) Error() string {
+	return fmt.Sprint(p.Value)
+}
+
+// Unwrap returns Value if it is an error, or nil otherwise.
+func (p *PanicError) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// panicMetadata returns metadata that describes the structure of
+// the panic value v.
+func panicMetadata(v interface{}) Fields {
+	m := Fields{"panic_type": fmt.Sprintf("%T", v)}
+
+	err, ok := v.(error)
+	if !ok {
+		return m
+	}
+
+	var chain []string
+	for ; err != nil; err = errors.Unwrap(err) {
+		chain = append(chain, fmt.Sprintf("%T", err))
+	}
+
+	if len(chain) > 1 {
+		m["panic_chain"] = chain
+	}
+
+	return m
+}
+
+// Recover calls the default Logger's Recover method.
+func Recover(repanic bool) {
+	if r := recover(); r != nil {
+		defaultLogger.recovered(r, repanic)
+	}
+}
+
+// Recover recovers from a panic, if there is one, and logs
+// the panic value at the panic level along with its structure,
+// as described by Panicf. It must be deferred directly, for example:
+//
+//	defer l.Recover(false)
+//
+// If repanic is true, Recover panics again with the original value
+// after logging it.
+//
+// A *PanicError from Panic or Panicf has already been logged,
+// so it is not logged again.
+func (l *Logger) Recover(repanic bool) {
+	if r := recover(); r != nil {
+		l.recovered(r, repanic)
+	}
+}
+
+func (l *Logger) recovered(r interface{}, repanic bool) {
+	if _, ok := r.(*PanicError); !ok && l.enabled(PanicLevel) {
+		l.emit(0, PanicLevel, panicSite(), "", nil, nil, r)
+	}
+
+	if repanic {
+		panic(r)
+	}
+}
@@ -0,0 +1,37 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slog
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// panicSite returns the file name and line number that panicked,
+// which is the first frame after the runtime's panic handling.
+func panicSite() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	inPanic := false
+	for {
+		fr, more := frames.Next()
+
+		if fr.Function == "runtime.gopanic" {
+			inPanic = true
+		} else if inPanic && !strings.HasPrefix(fr.Function, "runtime.") {
+			file := fr.File
+			if slash := strings.LastIndex(file, "/"); slash >= 0 {
+				file = file[slash+1:]
+			}
+			return fmt.Sprintf("%s:%d", file, fr.Line)
+		}
+
+		if !more {
+			return "?:0"
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build tinygo
+// +build tinygo
+
+package slog
+
+// panicSite returns an unknown file name and line number,
+// because TinyGo does not support walking the stack of a panic.
+func panicSite() string {
+	return "?:0"
+}
@@ -0,0 +1,134 @@
+package slog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPanicMetadata(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mw  = &mockWriter{}
+		l   = NewLogger(WithOutput(mw))
+		err = fmt.Errorf("opening config: %w", &os.PathError{Op: "open", Path: "x", Err: os.ErrNotExist})
+	)
+
+	func() {
+		defer func() { recover() }()
+		l.Panic(err)
+	}()
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Metadata["panic_type"] != "*fmt.wrapError" {
+		t.Fatalf("expected panic_type '%s', got '%v'", "*fmt.wrapError", e.Metadata["panic_type"])
+	}
+
+	chain := fmt.Sprint(e.Metadata["panic_chain"])
+	expChain := "[*fmt.wrapError *fs.PathError *errors.errorString]"
+	if chain != expChain {
+		t.Fatalf("expected panic_chain '%s', got '%s'", expChain, chain)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw))
+
+	func() {
+		defer l.Recover(false)
+		panic(42)
+	}()
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Metadata["level"] != string(PanicLevel) {
+		t.Fatalf("expected level '%s', got '%s'", PanicLevel, e.Metadata["level"])
+	}
+
+	if e.Metadata["panic_type"] != "int" {
+		t.Fatalf("expected panic_type '%s', got '%v'", "int", e.Metadata["panic_type"])
+	}
+
+	if e.Message != "42" {
+		t.Fatalf("expected message '%s', got '%s'", "42", e.Message)
+	}
+
+	file := fmt.Sprint(e.Metadata["file"])
+	if !strings.HasPrefix(file, "panic_test.go:") {
+		t.Fatalf("expected file to contain '%s', got '%s'", "panic_test.go", file)
+	}
+
+	var r interface{}
+	func() {
+		defer func() { r = recover() }()
+		func() {
+			defer l.Recover(true)
+			panic(42)
+		}()
+	}()
+
+	if r != 42 {
+		t.Fatalf("expected repanic with '%d', got '%v'", 42, r)
+	}
+}
+
+func TestPanicError(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mw = &mockWriter{}
+		l  = NewLogger(WithOutput(mw))
+		r  interface{}
+	)
+
+	func() {
+		defer func() { r = recover() }()
+		l.Panicf(Fields{"k": "v"}, os.ErrNotExist)
+	}()
+
+	pe, ok := r.(*PanicError)
+	if !ok {
+		t.Fatalf("expected a *PanicError, got '%T'", r)
+	}
+
+	if pe.Value != os.ErrNotExist {
+		t.Fatalf("expected value '%v', got '%v'", os.ErrNotExist, pe.Value)
+	}
+
+	if pe.Error() != os.ErrNotExist.Error() {
+		t.Fatalf("expected error '%s', got '%s'", os.ErrNotExist, pe.Error())
+	}
+
+	if pe.Unwrap() != os.ErrNotExist {
+		t.Fatalf("expected to unwrap '%v', got '%v'", os.ErrNotExist, pe.Unwrap())
+	}
+
+	if pe.Event != string(mw.byt[:len(mw.byt)-1]) {
+		t.Fatalf("expected event '%s', got '%s'", mw.byt, pe.Event)
+	}
+
+	w := &linesWriter{}
+	l = NewLogger(WithOutput(w))
+
+	func() {
+		defer l.Recover(false)
+		l.Panic("once")
+	}()
+
+	if len(w.lines) != 1 {
+		t.Fatalf("expected '%d' line(s), got '%d'", 1, len(w.lines))
+	}
+}
@@ -0,0 +1,95 @@
+package slog
+
+import (
+	"fmt"
+	"os"
+)
+
+// sprintf is a message formatted with fmt.Sprintf only if its event
+// is logged, so that disabled levels do not pay for formatting.
+type sprintf struct {
+	format string
+	args   []interface{}
+}
+
+// LogValue formats the message.
+func (s sprintf) LogValue() interface{} {
+	return fmt.Sprintf(s.format, s.args...)
+}
+
+// Tracem calls the default Logger's Tracem method.
+func Tracem(format string, args ...interface{}) {
+	defaultLogger.Tracem(format, args...)
+}
+
+// Debugm calls the default Logger's Debugm method.
+func Debugm(format string, args ...interface{}) {
+	defaultLogger.Debugm(format, args...)
+}
+
+// Infom calls the default Logger's Infom method.
+func Infom(format string, args ...interface{}) {
+	defaultLogger.Infom(format, args...)
+}
+
+// Warnm calls the default Logger's Warnm method.
+func Warnm(format string, args ...interface{}) {
+	defaultLogger.Warnm(format, args...)
+}
+
+// Errorm calls the default Logger's Errorm method.
+func Errorm(format string, args ...interface{}) {
+	defaultLogger.Errorm(format, args...)
+}
+
+// Panicm calls the default Logger's Panicm method.
+func Panicm(format string, args ...interface{}) {
+	defaultLogger.Panicm(format, args...)
+}
+
+// Fatalm calls the default Logger's Fatalm method.
+func Fatalm(format string, args ...interface{}) {
+	defaultLogger.Fatalm(format, args...)
+}
+
+// Tracem logs a message formatted as with fmt.Sprintf at the trace level.
+// Unlike the methods ending in f, which take fields, the methods ending
+// in m take a format and its arguments:
+//
+//	l.Infom("connected to %s in %v", host, d)
+func (l *Logger) Tracem(format string, args ...interface{}) {
+	l.log(TraceLevel, nil, sprintf{format: format, args: args})
+}
+
+// Debugm logs a message formatted as with fmt.Sprintf at the debug level.
+func (l *Logger) Debugm(format string, args ...interface{}) {
+	l.log(DebugLevel, nil, sprintf{format: format, args: args})
+}
+
+// Infom logs a message formatted as with fmt.Sprintf at the info level.
+func (l *Logger) Infom(format string, args ...interface{}) {
+	l.log(InfoLevel, nil, sprintf{format: format, args: args})
+}
+
+// Warnm logs a message formatted as with fmt.Sprintf at the warn level.
+func (l *Logger) Warnm(format string, args ...interface{}) {
+	l.log(WarnLevel, nil, sprintf{format: format, args: args})
+}
+
+// Errorm logs a message formatted as with fmt.Sprintf at the error level.
+func (l *Logger) Errorm(format string, args ...interface{}) {
+	l.log(ErrorLevel, nil, sprintf{format: format, args: args})
+}
+
+// Panicm logs a message formatted as with fmt.Sprintf at the panic level
+// and then panics with a *PanicError that holds the message.
+func (l *Logger) Panicm(format string, args ...interface{}) {
+	l.log(PanicLevel, nil, fmt.Sprintf(format, args...))
+}
+
+// Fatalm logs a message formatted as with fmt.Sprintf at the fatal level
+// followed by os.Exit(1).
+func (l *Logger) Fatalm(format string, args ...interface{}) {
+	l.log(FatalLevel, nil, sprintf{format: format, args: args})
+	os.Exit(1)
+}
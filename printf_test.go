@@ -0,0 +1,50 @@
+package slog
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type printfTestCounter struct{ n *int }
+
+func (c printfTestCounter) String() string {
+	*c.n++
+	return "counted"
+}
+
+func TestPrintf(t *testing.T) {
+	t.Parallel()
+
+	mw := &mockWriter{}
+	l := NewLogger(WithOutput(mw), WithLevel(InfoLevel))
+
+	var n int
+	l.Debugm("%s", printfTestCounter{n: &n})
+	if n != 0 {
+		t.Fatalf("expected '%d' format(s) for a dropped event, got '%d'", 0, n)
+	}
+
+	l.Infom("connected to %s in %v after %d %s", "db", "1s", 3, printfTestCounter{n: &n})
+	if n != 1 {
+		t.Fatalf("expected '%d' format(s) for a logged event, got '%d'", 1, n)
+	}
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := "connected to db in 1s after 3 counted"; e.Message != exp {
+		t.Fatalf("expected message '%s', got '%s'", exp, e.Message)
+	}
+
+	func() {
+		defer func() {
+			pe, ok := recover().(*PanicError)
+			if !ok || pe.Value != "boom 1" {
+				t.Fatalf("expected a *PanicError with value '%s', got '%v'", "boom 1", pe)
+			}
+		}()
+		l.Panicm("boom %d", 1)
+	}()
+}
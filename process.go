@@ -0,0 +1,25 @@
+package slog
+
+import (
+	"os"
+	"strconv"
+)
+
+// WithProcessInfo makes the Logger log the "hostname" of the machine,
+// the "pid" of the process, and, if it is not empty, the name of the
+// "service" in the metadata of every event. The hostname and pid are
+// looked up once, when the Logger is created; the hostname is omitted
+// if it cannot be found.
+func WithProcessInfo(service string) Option {
+	return func(l *Logger) {
+		if service != "" {
+			l.setRunMetadata("service", service)
+		}
+
+		l.setRunMetadata("pid", strconv.Itoa(os.Getpid()))
+
+		if h, err := os.Hostname(); err == nil {
+			l.setRunMetadata("hostname", h)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package slog
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func TestWithProcessInfo(t *testing.T) {
+	t.Parallel()
+
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name    string
+		service string
+		exp     map[string]string
+	}{
+		{
+			name:    "service",
+			service: "api",
+			exp:     map[string]string{"hostname": host, "pid": strconv.Itoa(os.Getpid()), "service": "api"},
+		},
+		{
+			name: "no service",
+			exp:  map[string]string{"hostname": host, "pid": strconv.Itoa(os.Getpid())},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			NewLogger(WithOutput(mw), WithProcessInfo(test.service), WithRunID()).Named("a").Info("hello")
+
+			var e struct {
+				Metadata map[string]string `json:"_metadata"`
+			}
+			if err := json.Unmarshal(mw.byt, &e); err != nil {
+				t.Fatal(err)
+			}
+
+			for k, v := range test.exp {
+				if e.Metadata[k] != v {
+					t.Fatalf("expected '%s' to be '%s', got '%s'", k, v, e.Metadata[k])
+				}
+			}
+
+			if _, ok := e.Metadata["service"]; !ok && test.service != "" {
+				t.Fatal("expected a service, got none")
+			} else if ok && test.service == "" {
+				t.Fatalf("expected no service, got '%s'", e.Metadata["service"])
+			}
+
+			if e.Metadata["run_id"] != RunID() {
+				t.Fatalf("expected run_id '%s', got '%s'", RunID(), e.Metadata["run_id"])
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package slog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// SinkFactory creates a sink, which is where a Logger writes events,
+// from named parameters.
+type SinkFactory func(params map[string]string) (io.Writer, error)
+
+// HookFactory creates a Hook, such as an enricher that adds fields
+// to events, from named parameters.
+type HookFactory func(params map[string]string) (Hook, error)
+
+var (
+	registryMu sync.RWMutex
+	sinks      = map[string]SinkFactory{
+		"stdout": func(map[string]string) (io.Writer, error) { return os.Stdout, nil },
+		"stderr": func(map[string]string) (io.Writer, error) { return os.Stderr, nil },
+		"file":   newFileSink,
+	}
+	hooks = map[string]HookFactory{}
+)
+
+// RegisterSink makes a sink available by name, so that it can be created
+// with NewSink, for example from configuration, without this package
+// importing every integration. It is intended to be called from
+// an init function.
+//
+// The sinks "stdout", "stderr", and "file" are built in. The "file" sink
+// appends to the file named by its "path" parameter.
+//
+// RegisterSink panics if name is already registered or fn is nil.
+func RegisterSink(name string, fn SinkFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if fn == nil {
+		panic("slog: RegisterSink factory is nil")
+	}
+
+	if _, ok := sinks[name]; ok {
+		panic("slog: RegisterSink called twice for sink " + name)
+	}
+
+	sinks[name] = fn
+}
+
+// RegisterHook makes a Hook available by name, so that it can be created
+// with NewHook. It is intended to be called from an init function.
+//
+// RegisterHook panics if name is already registered or fn is nil.
+func RegisterHook(name string, fn HookFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if fn == nil {
+		panic("slog: RegisterHook factory is nil")
+	}
+
+	if _, ok := hooks[name]; ok {
+		panic("slog: RegisterHook called twice for hook " + name)
+	}
+
+	hooks[name] = fn
+}
+
+// NewSink creates the sink registered as name with params.
+func NewSink(name string, params map[string]string) (io.Writer, error) {
+	registryMu.RLock()
+	fn, ok := sinks[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("slog: unknown sink %q", name)
+	}
+
+	return fn(params)
+}
+
+// NewHook creates the Hook registered as name with params.
+func NewHook(name string, params map[string]string) (Hook, error) {
+	registryMu.RLock()
+	fn, ok := hooks[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("slog: unknown hook %q", name)
+	}
+
+	return fn(params)
+}
+
+// Sinks returns the sorted names of the registered sinks.
+func Sinks() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(sinks))
+	for name := range sinks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Hooks returns the sorted names of the registered hooks.
+func Hooks() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(hooks))
+	for name := range hooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func newFileSink(params map[string]string) (io.Writer, error) {
+	path := params["path"]
+	if path == "" {
+		return nil, fmt.Errorf("slog: file sink requires a path")
+	}
+
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+}
@@ -0,0 +1,118 @@
+package slog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// registryTestName returns a name that is unique across test runs,
+// because names cannot be registered twice.
+func registryTestName() string {
+	return fmt.Sprintf("registry-test-%d", atomic.AddInt64(&registryTestN, 1))
+}
+
+var registryTestN int64
+
+func TestRegisterSink(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mw   = &mockWriter{}
+		name = registryTestName()
+	)
+
+	RegisterSink(name, func(params map[string]string) (io.Writer, error) {
+		return mw, nil
+	})
+
+	w, err := NewSink(name, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w != mw {
+		t.Fatal("expected the registered sink, but got another")
+	}
+
+	found := false
+	for _, n := range Sinks() {
+		found = found || n == name
+	}
+
+	if !found {
+		t.Fatalf("expected Sinks to list '%s', but it did not", name)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic when registering twice, got none")
+			}
+		}()
+		RegisterSink(name, func(map[string]string) (io.Writer, error) { return nil, nil })
+	}()
+
+	if _, err := NewSink("registry-missing", nil); err == nil {
+		t.Fatal("expected an error for an unknown sink, got nil")
+	}
+}
+
+func TestRegisterHook(t *testing.T) {
+	t.Parallel()
+
+	name := registryTestName()
+	RegisterHook(name, func(params map[string]string) (Hook, error) {
+		return HookFunc(func(e *Event) {
+			e.Fields["env"] = params["env"]
+		}), nil
+	})
+
+	h, err := NewHook(name, map[string]string{"env": "prod"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := &Event{Fields: Fields{}}
+	h.Fire(e)
+
+	if e.Fields["env"] != "prod" {
+		t.Fatalf("expected field '%s', got '%s'", "prod", e.Fields["env"])
+	}
+
+	if _, err := NewHook("registry-missing", nil); err == nil {
+		t.Fatal("expected an error for an unknown hook, got nil")
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	if _, err := NewSink("file", nil); err == nil {
+		t.Fatal("expected an error without a path, got nil")
+	}
+
+	path := filepath.Join(dir, "out.log")
+	w, err := NewSink("file", map[string]string{"path": path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.(*os.File).Close()
+
+	NewLogger(WithOutput(w)).Info("hello")
+
+	byt, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(byt), `"message":"hello"`) {
+		t.Fatalf("expected the file to contain the event, got '%s'", byt)
+	}
+}
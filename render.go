@@ -0,0 +1,91 @@
+package slog
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// builtinEncode formats v with the built-in encoder for its type.
+// The built-in encoders render common standard library and
+// quasi-standard types in their canonical text form. They can be
+// replaced with RegisterEncoder. Types such as netip.Addr, whose
+// String method is already canonical, need no encoder.
+//
+// Every value is logged as a JSON string, so numbers of any size keep
+// their full precision, even in JavaScript-based log viewers that
+// would round JSON numbers above 2^53.
+func builtinEncode(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case net.IP:
+		return v.String(), true
+	case url.URL:
+		return stripUserinfo(&v), true
+	case *url.URL:
+		if v == nil {
+			return "nil", true
+		}
+		return stripUserinfo(v), true
+	case [16]byte:
+		return formatUUID(v), true
+	case big.Int:
+		return v.String(), true
+	case big.Float:
+		return v.Text('g', -1), true
+	case big.Rat:
+		return v.String(), true
+	case float64:
+		if ff, ok := floatFormat.Load().(floatFmt); ok {
+			return strconv.FormatFloat(v, ff.format, ff.prec, 64), true
+		}
+	case float32:
+		if ff, ok := floatFormat.Load().(floatFmt); ok {
+			return strconv.FormatFloat(float64(v), ff.format, ff.prec, 32), true
+		}
+	}
+
+	return "", false
+}
+
+// floatFormat holds the floatFmt set by SetFloatFormat, if any.
+var floatFormat atomic.Value
+
+type floatFmt struct {
+	format byte
+	prec   int
+}
+
+// stripUserinfo returns u as a string without its user information,
+// which may contain credentials.
+func stripUserinfo(u *url.URL) string {
+	c := *u
+	c.User = nil
+
+	return c.String()
+}
+
+// SetFloatFormat sets how float32 and float64 values are formatted,
+// using the format and precision of strconv.FormatFloat. For example,
+// to render latencies with exactly three decimals, rather than
+// switching to scientific notation for large values:
+//
+//	slog.SetFloatFormat('f', 3)
+//
+// Formatting does not depend on the locale. By default, floats are
+// formatted like SetFloatFormat('g', -1), which is the shortest
+// representation that round-trips.
+//
+// SetFloatFormat panics if format is not one of 'b', 'e', 'E', 'f',
+// 'g', 'G', 'x', or 'X'.
+func SetFloatFormat(format byte, prec int) {
+	if !strings.ContainsRune("beEfgGxX", rune(format)) {
+		panic(fmt.Sprintf("slog: SetFloatFormat format %q is invalid", format))
+	}
+
+	floatFormat.Store(floatFmt{format: format, prec: prec})
+	atomic.StoreUint32(&encodedKinds, atomic.LoadUint32(&encodedKinds)|1<<uint(floatKind))
+}
@@ -0,0 +1,78 @@
+package slog
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// runID identifies this run of the process.
+var runID = UUIDv4.NewID()
+
+// RunID returns the ID of this run of the process, a random UUID
+// generated when the process starts.
+func RunID() string {
+	return runID
+}
+
+// WithRunID makes the Logger log the "run_id" of the process, as
+// returned by RunID, in the metadata of every event, so that log
+// queries can tell the events from before and after a restart apart.
+func WithRunID() Option {
+	return func(l *Logger) {
+		l.setRunMetadata("run_id", runID)
+	}
+}
+
+// WithRestartCount makes the Logger log n as the "restart_count"
+// in the metadata of every event. n is usually the count returned
+// by IncrementRestartCount when the process starts.
+func WithRestartCount(n int) Option {
+	return func(l *Logger) {
+		l.setRunMetadata("restart_count", strconv.Itoa(n))
+	}
+}
+
+func (l *Logger) setRunMetadata(k string, v interface{}) {
+	run := Fields{k: v}
+	for k, v := range l.run {
+		if _, ok := run[k]; !ok {
+			run[k] = v
+		}
+	}
+
+	l.run = run
+}
+
+// IncrementRestartCount increments the count of restarts stored in the
+// file named path, creating it if it does not exist, and returns the new
+// count, which is 0 the first time the process starts. It is intended to
+// be called once when the process starts:
+//
+//	n, err := slog.IncrementRestartCount("/var/lib/app/restarts")
+//	if err != nil {
+//		// ...
+//	}
+//	l := slog.NewLogger(slog.WithRunID(), slog.WithRestartCount(n))
+func IncrementRestartCount(path string) (int, error) {
+	n := 0
+
+	byt, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		c, err := strconv.Atoi(strings.TrimSpace(string(byt)))
+		if err != nil {
+			return 0, fmt.Errorf("slog: restart count: %w", err)
+		}
+		n = c + 1
+	case !os.IsNotExist(err):
+		return 0, fmt.Errorf("slog: restart count: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(strconv.Itoa(n)+"\n"), 0o644); err != nil {
+		return 0, fmt.Errorf("slog: restart count: %w", err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,64 @@
+package slog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestRunID(t *testing.T) {
+	t.Parallel()
+
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(RunID()) {
+		t.Fatalf("expected a UUID, got '%s'", RunID())
+	}
+
+	mw := &mockWriter{}
+	NewLogger(WithOutput(mw), WithRunID(), WithRestartCount(3)).Named("a").Info("hello")
+
+	var e struct {
+		Metadata struct {
+			RunID        string `json:"run_id"`
+			RestartCount string `json:"restart_count"`
+		} `json:"_metadata"`
+	}
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Metadata.RunID != RunID() || e.Metadata.RestartCount != "3" {
+		t.Fatalf("expected run_id '%s' and restart_count '%d', got '%s'", RunID(), 3, mw.byt)
+	}
+}
+
+func TestIncrementRestartCount(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "restarts")
+
+	for exp := 0; exp < 3; exp++ {
+		n, err := IncrementRestartCount(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != exp {
+			t.Fatalf("expected count '%d', got '%d'", exp, n)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("garbage"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := IncrementRestartCount(path); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	if _, err := IncrementRestartCount(filepath.Join(dir, "missing", "restarts")); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
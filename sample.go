@@ -0,0 +1,114 @@
+package slog
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sampleWindow is the interval over which an adaptive sampler
+// measures throughput before it adjusts its rate.
+const sampleWindow = time.Second
+
+// SetSampling makes the Logger sample events adaptively so that
+// it writes about target events per second, keeping the volume, and
+// so the cost, of logs bounded without manual tuning.
+//
+// The Logger measures how many events are logged every second and,
+// during the next second, keeps one in every n events, choosing the
+// smallest n that would have met target. Whenever that fraction changes, the Logger
+// logs an info event with the new "sampling_rate", the "observed_rate",
+// and the "target_rate" as fields.
+//
+// Events at the error level, or a more severe level, are never dropped.
+// If target is not positive, sampling is turned off, which is the default.
+func (l *Logger) SetSampling(target int) {
+	if target < 0 {
+		target = 0
+	}
+
+	l.mu.Lock()
+	old := 0
+	if l.cfg.sampler != nil {
+		old = int(l.cfg.sampler.target)
+	}
+
+	if target == 0 {
+		l.cfg.sampler = nil
+	} else {
+		l.cfg.sampler = &sampler{target: float64(target), every: 1}
+	}
+	l.mu.Unlock()
+
+	l.configChanged("sampling", old, target)
+}
+
+type sampler struct {
+	mu     sync.Mutex
+	target float64
+	start  time.Time
+	seen   int
+
+	// every is the n of keeping one in every n events.
+	every int
+	n     int
+}
+
+// sample reports whether to keep an event logged at now. If the rate
+// was adjusted, it also returns a diagnostic event without a file.
+func (s *sampler) sample(now time.Time) (bool, *Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var diag *Event
+
+	if s.start.IsZero() {
+		s.start = now
+	} else if elapsed := now.Sub(s.start); elapsed >= sampleWindow {
+		observed := float64(s.seen) / elapsed.Seconds()
+
+		every := 1
+		if observed > s.target {
+			every = int(math.Ceil(observed / s.target))
+		}
+
+		if every != s.every {
+			s.every = every
+			s.n = 0
+			diag = &Event{
+				Level: InfoLevel,
+				Time:  now.UTC(),
+				Fields: Fields{
+					"sampling_rate": formatValue(1 / float64(every)),
+					"observed_rate": formatValue(observed),
+					"target_rate":   formatValue(s.target),
+				},
+				Message: "sampling rate adjusted",
+			}
+		}
+
+		s.start = now
+		s.seen = 0
+	}
+
+	s.seen++
+
+	s.n++
+	if s.n < s.every {
+		return false, diag
+	}
+	s.n = 0
+
+	return true, diag
+}
+
+// sample reports whether the Logger keeps an event at lv,
+// and returns a diagnostic event to write, if any.
+func (l *Logger) sample(lv Level) (bool, *Event) {
+	s := l.getConfig().sampler
+	if s == nil || isErrorLevel(lv) {
+		return true, nil
+	}
+
+	return s.sample(time.Now())
+}
@@ -0,0 +1,116 @@
+package slog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSampler(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s     = &sampler{target: 100, every: 1}
+		start = time.Now()
+	)
+
+	// run logs n events evenly over the second starting at sec,
+	// and returns how many were kept and the diagnostics.
+	run := func(sec, n int) (int, []*Event) {
+		var (
+			kept  int
+			diags []*Event
+		)
+
+		for i := 0; i < n; i++ {
+			now := start.Add(time.Duration(sec)*time.Second + time.Duration(i)*time.Second/time.Duration(n))
+
+			keep, diag := s.sample(now)
+			if keep {
+				kept++
+			}
+			if diag != nil {
+				diags = append(diags, diag)
+			}
+		}
+
+		return kept, diags
+	}
+
+	tests := []struct {
+		n     int
+		kept  int
+		diags int
+		rate  string
+	}{
+		{n: 1000, kept: 1000, diags: 0},
+		{n: 1000, kept: 100, diags: 1, rate: "0.1"},
+		{n: 1000, kept: 100, diags: 0},
+		{n: 50, kept: 5, diags: 0},
+		{n: 50, kept: 50, diags: 1, rate: "1"},
+	}
+
+	for i, test := range tests {
+		kept, diags := run(i, test.n)
+
+		if kept != test.kept {
+			t.Fatalf("second %d: expected '%d' kept event(s), got '%d'", i, test.kept, kept)
+		}
+
+		if len(diags) != test.diags {
+			t.Fatalf("second %d: expected '%d' diagnostic(s), got '%d'", i, test.diags, len(diags))
+		}
+
+		if test.diags > 0 && diags[0].Fields["sampling_rate"] != test.rate {
+			t.Fatalf(
+				"second %d: expected sampling_rate '%s', got '%s'",
+				i,
+				test.rate,
+				diags[0].Fields["sampling_rate"],
+			)
+		}
+	}
+}
+
+func TestSetSampling(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w))
+	l.SetSampling(1)
+
+	// Move the sampler into a window where it keeps
+	// a small fraction of events.
+	s := l.getConfig().sampler
+	s.start = time.Now().Add(-sampleWindow)
+	s.seen = 1000
+
+	l.Info("sampled")
+	l.Info("sampled")
+	l.Error("kept")
+
+	var msgs []string
+	for _, line := range w.lines {
+		var e event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		msgs = append(msgs, e.Message.(string))
+
+		if e.Message == "sampling rate adjusted" && e.Metadata["file"] == "" {
+			t.Fatal("expected the diagnostic to have a file, but it did not")
+		}
+	}
+
+	if len(msgs) != 2 || msgs[0] != "sampling rate adjusted" || msgs[1] != "kept" {
+		t.Fatalf("expected the diagnostic and the error, got '%v'", msgs)
+	}
+
+	l.SetSampling(0)
+	l.Info("unsampled")
+
+	if len(w.lines) != 3 {
+		t.Fatalf("expected '%d' line(s) after sampling is off, got '%d'", 3, len(w.lines))
+	}
+}
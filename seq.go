@@ -0,0 +1,20 @@
+package slog
+
+import "sync/atomic"
+
+// WithSequence makes the Logger number the events it writes, logging
+// "seq" in the metadata as an integer that starts at 1 and increases by
+// one with every event, so that consumers can detect lost events and
+// order events whose times are equal. Loggers derived with Named or With
+// share the sequence of the Logger they were derived from, since they
+// write to the same output.
+func WithSequence() Option {
+	return func(l *Logger) {
+		l.seq = new(uint64)
+	}
+}
+
+// nextSeq returns the next number of the Logger's sequence.
+func (l *Logger) nextSeq() uint64 {
+	return atomic.AddUint64(l.seq, 1)
+}
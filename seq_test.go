@@ -0,0 +1,73 @@
+package slog
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestWithSequence(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w), WithSequence())
+	named := l.Named("a")
+
+	const n = 50
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				l.Info("hello")
+			} else {
+				named.Info("hello")
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var seqs []int
+	for _, line := range w.lines {
+		var e struct {
+			Metadata struct {
+				Seq *int `json:"seq"`
+			} `json:"_metadata"`
+		}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Metadata.Seq == nil {
+			t.Fatalf("expected a seq, got none in '%s'", line)
+		}
+		seqs = append(seqs, *e.Metadata.Seq)
+	}
+
+	sort.Ints(seqs)
+
+	if len(seqs) != n {
+		t.Fatalf("expected '%d' event(s), got '%d'", n, len(seqs))
+	}
+
+	for i, s := range seqs {
+		if s != i+1 {
+			t.Fatalf("expected seqs 1 to %d, got '%v'", n, seqs)
+		}
+	}
+
+	mw := &mockWriter{}
+	NewLogger(WithOutput(mw)).Info("hello")
+
+	var e event
+	if err := json.Unmarshal(mw.byt, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := e.Metadata["seq"]; ok {
+		t.Fatal("expected no seq by default, got one")
+	}
+}
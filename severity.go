@@ -0,0 +1,123 @@
+package slog
+
+// SeverityTable maps level names to the severities of a foreign
+// severity system, so that sinks and collectors map levels,
+// including custom ones, consistently.
+//
+// The built-in tables may be extended, for example with custom levels,
+// but only before any Logger uses them.
+type SeverityTable map[Level]interface{}
+
+var (
+	// SyslogSeverity maps levels to syslog numeric severities (RFC 5424).
+	SyslogSeverity = SeverityTable{
+		"trace": 7,
+		"debug": 7,
+		"info":  6,
+		"warn":  4,
+		"error": 3,
+		"panic": 2,
+		"fatal": 2,
+	}
+
+	// GCPSeverity maps levels to Google Cloud Logging LogSeverity names.
+	GCPSeverity = SeverityTable{
+		"trace": "DEBUG",
+		"debug": "DEBUG",
+		"info":  "INFO",
+		"warn":  "WARNING",
+		"error": "ERROR",
+		"panic": "CRITICAL",
+		"fatal": "ALERT",
+	}
+
+	// OTLPSeverity maps levels to OpenTelemetry log SeverityNumbers.
+	OTLPSeverity = SeverityTable{
+		"trace": 1,
+		"debug": 5,
+		"info":  9,
+		"warn":  13,
+		"error": 17,
+		"panic": 21,
+		"fatal": 24,
+	}
+
+	// WindowsEventType maps levels to Windows event log event types.
+	WindowsEventType = SeverityTable{
+		"trace": 4,
+		"debug": 4,
+		"info":  4,
+		"warn":  2,
+		"error": 1,
+		"panic": 1,
+		"fatal": 1,
+	}
+)
+
+// Severity returns the severity of lv and true, or false if t does
+// not map lv. A level registered with RegisterLevel that t does not map
+// gets the severity of the closest less severe level that t maps,
+// so that for example a "notice" level between info and warn maps
+// like info.
+func (t SeverityTable) Severity(lv Level) (interface{}, bool) {
+	if s, ok := t[lv]; ok {
+		return s, true
+	}
+
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+
+	sev, ok := levelSeverity[lv]
+	if !ok {
+		return nil, false
+	}
+
+	var (
+		best    interface{}
+		bestLv  Level
+		bestSev int
+		found   bool
+	)
+	for k, v := range t {
+		ks, ok := levelSeverity[k]
+		if !ok || ks > sev {
+			continue
+		}
+
+		if !found || ks > bestSev || ks == bestSev && k < bestLv {
+			best, bestLv, bestSev, found = v, k, ks, true
+		}
+	}
+
+	return best, found
+}
+
+// SetSeverity makes the Logger add the severity of each event's level,
+// according to t, to its metadata under key. For example, for Google
+// Cloud Logging:
+//
+//	l.SetSeverity("severity", slog.GCPSeverity)
+//
+// Levels that t does not map, and that are not registered custom
+// levels above one it does, get no severity. If t is nil, no severity is added, which is the default.
+func (l *Logger) SetSeverity(key string, t SeverityTable) {
+	l.mu.Lock()
+	old := l.cfg.severityKey
+	l.cfg.severityKey = key
+	l.cfg.severity = t
+	l.mu.Unlock()
+
+	l.configChanged("severity_key", old, key)
+}
+
+func (c config) addSeverity(e *Event) {
+	sev, ok := c.severity.Severity(e.Level)
+	if !ok {
+		return
+	}
+
+	if e.Metadata == nil {
+		e.Metadata = Fields{}
+	}
+	e.Metadata[c.severityKey] = sev
+}
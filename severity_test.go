@@ -0,0 +1,100 @@
+package slog
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		table SeverityTable
+		lv    Level
+		exp   interface{}
+	}{
+		{name: "syslog", table: SyslogSeverity, lv: WarnLevel, exp: float64(4)},
+		{name: "gcp", table: GCPSeverity, lv: ErrorLevel, exp: "ERROR"},
+		{name: "otlp", table: OTLPSeverity, lv: InfoLevel, exp: float64(9)},
+		{name: "windows", table: WindowsEventType, lv: TraceLevel, exp: float64(4)},
+		{name: "unmapped", table: SeverityTable{}, lv: InfoLevel},
+		{name: "none", lv: InfoLevel},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			l := NewLogger(WithOutput(mw))
+			l.SetSeverity("severity", test.table)
+
+			fn := getLogFunc(t, l, test.lv, "hello")
+			fn("hello")
+
+			var e event
+			if err := json.Unmarshal(mw.byt, &e); err != nil {
+				t.Fatal(err)
+			}
+
+			sev, ok := e.Metadata["severity"]
+			if test.exp == nil {
+				if ok {
+					t.Fatalf("expected no severity, got '%v'", sev)
+				}
+				return
+			}
+
+			if sev != test.exp {
+				t.Fatalf("expected severity '%v', got '%v'", test.exp, sev)
+			}
+		})
+	}
+}
+
+func TestSeverityCustomLevel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		n      = atomic.AddInt64(&levelTestN, 1)
+		notice = RegisterLevel(fmt.Sprintf("notice-%d", n), 35)
+		lowest = RegisterLevel(fmt.Sprintf("lowest-%d", n), 5)
+	)
+
+	tests := []struct {
+		name  string
+		table SeverityTable
+		lv    Level
+		exp   interface{}
+	}{
+		{name: "closest less severe", table: GCPSeverity, lv: notice, exp: "INFO"},
+		{name: "mapped", table: SeverityTable{notice: "NOTICE"}, lv: notice, exp: "NOTICE"},
+		{name: "below every mapped level", table: GCPSeverity, lv: lowest},
+		{name: "unregistered", table: GCPSeverity, lv: "unregistered"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			sev, ok := test.table.Severity(test.lv)
+			if test.exp == nil {
+				if ok {
+					t.Fatalf("expected no severity, got '%v'", sev)
+				}
+				return
+			}
+
+			if sev != test.exp {
+				t.Fatalf("expected severity '%v', got '%v'", test.exp, sev)
+			}
+		})
+	}
+}
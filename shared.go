@@ -0,0 +1,118 @@
+package slog
+
+import (
+	"io"
+	"sync"
+)
+
+// SharedSink is a sink, such as a file or network connection, that
+// several Loggers write to, for example in an app whose modules each
+// create their own Logger. It counts references to the sink, so that
+// the sink is only flushed and closed when the last Logger using it
+// is closed, rather than when the first one is.
+//
+// Every Logger gets its own reference from Acquire:
+//
+//	s := slog.NewSharedSink(f)
+//	a := slog.NewLogger(slog.WithOutput(s.Acquire()))
+//	b := slog.NewLogger(slog.WithOutput(s.Acquire()))
+//
+// Writes to the sink are serialized, so it need not be safe
+// for concurrent use.
+type SharedSink struct {
+	mu   sync.Mutex
+	w    io.Writer
+	refs int
+}
+
+// NewSharedSink returns a SharedSink that writes to w.
+func NewSharedSink(w io.Writer) *SharedSink {
+	return &SharedSink{w: w}
+}
+
+// Acquire returns a new reference to the sink. Closing the reference,
+// for example by closing the Logger that writes to it, releases it;
+// once every reference is released, the sink is flushed, if it has
+// a Flush or Sync method, and closed, if it is an io.Closer.
+//
+// Acquire panics if the sink was already closed.
+func (s *SharedSink) Acquire() io.WriteCloser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.w == nil {
+		panic("slog: SharedSink acquired after it was closed")
+	}
+
+	s.refs++
+
+	return &sinkRef{s: s}
+}
+
+func (s *SharedSink) release() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refs--
+	if s.refs > 0 {
+		return nil
+	}
+
+	w := s.w
+	s.w = nil
+
+	var err error
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		err = f.Flush()
+	case interface{ Sync() error }:
+		err = f.Sync()
+	}
+
+	if c, ok := w.(io.Closer); ok {
+		if cErr := c.Close(); err == nil {
+			err = cErr
+		}
+	}
+
+	return err
+}
+
+// sinkRef is a reference to a SharedSink.
+type sinkRef struct {
+	s        *SharedSink
+	mu       sync.Mutex
+	released bool
+}
+
+func (r *sinkRef) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	released := r.released
+	r.mu.Unlock()
+
+	if released {
+		return 0, ErrClosed
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if r.s.w == nil {
+		return 0, ErrClosed
+	}
+
+	return r.s.w.Write(p)
+}
+
+// Close releases the reference. Closing it again has no effect.
+func (r *sinkRef) Close() error {
+	r.mu.Lock()
+	if r.released {
+		r.mu.Unlock()
+		return nil
+	}
+	r.released = true
+	r.mu.Unlock()
+
+	return r.s.release()
+}
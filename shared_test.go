@@ -0,0 +1,110 @@
+package slog
+
+import (
+	"sync"
+	"testing"
+)
+
+type flushCloseWriter struct {
+	linesWriter
+	flushed int
+	closed  int
+}
+
+func (w *flushCloseWriter) Flush() error {
+	w.flushed++
+	return nil
+}
+
+func (w *flushCloseWriter) Close() error {
+	w.closed++
+	return nil
+}
+
+func TestSharedSink(t *testing.T) {
+	t.Parallel()
+
+	var (
+		w = &flushCloseWriter{}
+		s = NewSharedSink(w)
+		a = NewLogger(WithOutput(s.Acquire()))
+		b = NewLogger(WithOutput(s.Acquire()))
+	)
+
+	a.Info("a")
+	b.Info("b")
+
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.flushed != 0 || w.closed != 0 {
+		t.Fatalf("expected the sink to stay open, got '%d' flush(es) and '%d' close(s)", w.flushed, w.closed)
+	}
+
+	b.Info("b")
+
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.flushed != 1 || w.closed != 1 {
+		t.Fatalf("expected the sink to be flushed and closed once, got '%d' and '%d'", w.flushed, w.closed)
+	}
+
+	if len(w.lines) != 3 {
+		t.Fatalf("expected '%d' line(s), got '%d'", 3, len(w.lines))
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic acquiring a closed sink, but did not get one")
+			}
+		}()
+		s.Acquire()
+	}()
+}
+
+func TestSharedSinkRef(t *testing.T) {
+	t.Parallel()
+
+	var (
+		w = &flushCloseWriter{}
+		s = NewSharedSink(w)
+		r = s.Acquire()
+		o = s.Acquire()
+	)
+
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Write([]byte("x")); err != ErrClosed {
+		t.Fatalf("expected '%v' writing to a released reference, got '%v'", ErrClosed, err)
+	}
+
+	if w.closed != 0 {
+		t.Fatal("expected closing a reference twice to release it once, but the sink was closed")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				o.Write([]byte("line"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(w.lines) != 400 {
+		t.Fatalf("expected '%d' line(s), got '%d'", 400, len(w.lines))
+	}
+}
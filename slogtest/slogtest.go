@@ -0,0 +1,79 @@
+// Package slogtest attaches the names of Go tests to the events logged
+// while they run, so that the events of parallel tests can be told
+// apart when a test fails.
+package slogtest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/safe-waters/slog"
+)
+
+// Key is the key of the field that holds the name of the test.
+const Key = "test"
+
+type testKey struct{}
+
+func init() {
+	slog.RegisterContextExtractor(func(ctx context.Context) slog.Fields {
+		if name, ok := ctx.Value(testKey{}).(string); ok {
+			return slog.Fields{Key: name}
+		}
+		return nil
+	})
+}
+
+// WithT returns a copy of ctx that carries the name of t, so that the
+// events logged with it by the methods that take a context, such as
+// InfoCtx, have the name of t as the "test" field. It lets tests tag
+// the events of the code under test that logs with the contexts it
+// is given:
+//
+//	ctx := slogtest.WithT(context.Background(), t)
+//	srv.Handle(ctx, req)
+func WithT(ctx context.Context, t testing.TB) context.Context {
+	return context.WithValue(ctx, testKey{}, t.Name())
+}
+
+// New returns a Logger for t that logs its events with t.Log, so that
+// they are only shown for failed tests or with go test -v, and that has
+// the name of t as the permanent "test" field. opts configure the
+// Logger as for slog.NewLogger, except for its output.
+//
+// Events logged after t completes are discarded, because t.Log
+// panics once its test has completed.
+func New(t testing.TB, opts ...slog.Option) *slog.Logger {
+	w := &testWriter{t: t}
+	t.Cleanup(w.done)
+
+	opts = append(opts, slog.WithOutput(w))
+
+	return slog.NewLogger(opts...).With(slog.Fields{Key: t.Name()})
+}
+
+// testWriter writes to t.Log until its test completes.
+type testWriter struct {
+	mu        sync.Mutex
+	t         testing.TB
+	completed bool
+}
+
+func (w *testWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.completed {
+		w.t.Log(strings.TrimSuffix(string(p), "\n"))
+	}
+
+	return len(p), nil
+}
+
+func (w *testWriter) done() {
+	w.mu.Lock()
+	w.completed = true
+	w.mu.Unlock()
+}
@@ -0,0 +1,83 @@
+package slogtest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/safe-waters/slog"
+)
+
+type linesWriter struct{ lines []string }
+
+func (w *linesWriter) Write(p []byte) (n int, err error) {
+	w.lines = append(w.lines, strings.TrimSpace(string(p)))
+	return len(p), nil
+}
+
+// recorder records the messages logged to it with Log.
+type recorder struct {
+	testing.TB
+	name string
+	logs []string
+}
+
+func (r *recorder) Name() string         { return r.name }
+func (r *recorder) Cleanup(func())       {}
+func (r *recorder) Log(a ...interface{}) { r.logs = append(r.logs, a[0].(string)) }
+
+func TestWithT(t *testing.T) {
+	t.Parallel()
+
+	w := &linesWriter{}
+	l := slog.NewLogger(slog.WithOutput(w))
+
+	l.InfoCtx(WithT(context.Background(), t), "tagged")
+	l.InfoCtx(context.Background(), "untagged")
+
+	exp := []string{"TestWithT", ""}
+	for i, line := range w.lines {
+		var e struct {
+			Fields map[string]string `json:"fields"`
+		}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Fields[Key] != exp[i] {
+			t.Fatalf("expected test '%s', got '%s'", exp[i], e.Fields[Key])
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	r := &recorder{TB: t, name: "TestNew/sub"}
+	l := New(r, slog.WithLevel(slog.InfoLevel))
+
+	l.Debug("dropped")
+	l.Infof(slog.Fields{"a": "b"}, "hello")
+
+	if len(r.logs) != 1 {
+		t.Fatalf("expected '%d' log(s), got '%d'", 1, len(r.logs))
+	}
+
+	exp := `"fields":{"a":"b","test":"TestNew/sub"},"message":"hello"}`
+	if !strings.HasSuffix(r.logs[0], exp) {
+		t.Fatalf("expected '%s' to end with '%s'", r.logs[0], exp)
+	}
+}
+
+func TestNewAfterCompletion(t *testing.T) {
+	t.Parallel()
+
+	var l *slog.Logger
+	t.Run("sub", func(t *testing.T) {
+		l = New(t)
+	})
+
+	// Logging after the subtest completed must not panic.
+	l.Info("late")
+}
@@ -0,0 +1,239 @@
+// Package slogvet checks Go source files for misuse of slog's idioms,
+// in the manner of go vet. It reports:
+//
+//   - messages built with fmt.Sprintf, whose values would be better
+//     logged as fields, so that they can be queried
+//   - Fields literals with keys that slog reserves for the metadata
+//     and the top-level keys of events, or with empty keys. The keys
+//     of groups nested in Fields literals may be reserved keys.
+//
+// Calls are checked if they are logging functions of slog or logging
+// methods of *slog.Logger, as resolved with type information, so that
+// methods of other types with the same names are not reported.
+// Fields literals and fmt.Sprintf calls are recognized syntactically.
+// The cmd/slogvet command type-checks packages and runs the checks
+// on them.
+package slogvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+)
+
+// ImportPath is the import path of slog.
+const ImportPath = "github.com/safe-waters/slog"
+
+// ReservedKeys are the keys that fields should not have, because slog
+// uses them for the metadata and the top-level keys of events.
+var ReservedKeys = map[string]bool{
+	"_metadata":   true,
+	"fields":      true,
+	"message":     true,
+	"level":       true,
+	"time":        true,
+	"file":        true,
+	"function":    true,
+	"event_id":    true,
+	"fingerprint": true,
+	"logger":      true,
+	"goroutine":   true,
+	"seq":         true,
+	"run_id":      true,
+}
+
+// Diagnostic is a problem found in a file.
+type Diagnostic struct {
+	Pos     token.Position
+	Message string
+}
+
+// String formats d like go vet, as "file:line:col: message".
+func (d Diagnostic) String() string {
+	return d.Pos.String() + ": " + d.Message
+}
+
+// msgIndex maps the suffixes of the logging methods to the index
+// of their message argument. The methods ending in m take a format,
+// so they are not checked.
+var msgIndex = map[string]int{
+	"":    0,
+	"f":   1,
+	"s":   0,
+	"w":   0,
+	"Ctx": 1,
+}
+
+var levels = []string{"Trace", "Debug", "Info", "Warn", "Error", "Panic", "Fatal"}
+
+// messageArg returns the index of the message argument of the logging
+// method named name, and whether name is a logging method.
+func messageArg(name string) (int, bool) {
+	switch name {
+	case "Log", "LogDepth":
+		return 2, true
+	case "LogCtx":
+		return 3, true
+	}
+
+	for _, lv := range levels {
+		if len(name) < len(lv) || name[:len(lv)] != lv {
+			continue
+		}
+		i, ok := msgIndex[name[len(lv):]]
+		return i, ok
+	}
+
+	return 0, false
+}
+
+// CheckFile returns the diagnostics of f, whose positions are in fset.
+// info holds the types of f's package, as computed by go/types, and
+// must record at least Uses. Calls whose functions info does not
+// resolve, for example because slog could not be imported, are not
+// checked.
+func CheckFile(fset *token.FileSet, f *ast.File, info *types.Info) []Diagnostic {
+	var (
+		slogName = importName(f, ImportPath, "slog")
+		fmtName  = importName(f, "fmt", "fmt")
+	)
+
+	if slogName == "" {
+		return nil
+	}
+
+	var (
+		ds []Diagnostic
+		// groups are the Fields literals nested in other Fields
+		// literals, whose keys cannot collide with slog's.
+		groups = map[*ast.CompositeLit]bool{}
+	)
+	report := func(n ast.Node, msg string) {
+		ds = append(ds, Diagnostic{Pos: fset.Position(n.Pos()), Message: msg})
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			i, ok := messageArg(sel.Sel.Name)
+			if !ok || i >= len(n.Args) || !isLoggingFunc(info, sel) {
+				return true
+			}
+
+			if isCall(n.Args[i], fmtName, "Sprintf") {
+				report(n.Args[i], "message built with fmt.Sprintf: log its values as fields instead")
+			}
+
+		case *ast.CompositeLit:
+			if !isSelector(n.Type, slogName, "Fields") {
+				return true
+			}
+
+			for _, elt := range n.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+
+				if g, ok := kv.Value.(*ast.CompositeLit); ok {
+					groups[g] = true
+				}
+
+				lit, ok := kv.Key.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+
+				k, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					continue
+				}
+
+				switch {
+				case k == "":
+					report(kv.Key, "field with an empty key")
+				case ReservedKeys[k] && !groups[n]:
+					report(kv.Key, "field key "+strconv.Quote(k)+" is reserved by slog")
+				}
+			}
+		}
+
+		return true
+	})
+
+	sort.Slice(ds, func(i, j int) bool { return ds[i].Pos.Offset < ds[j].Pos.Offset })
+
+	return ds
+}
+
+// importName returns the name that f imports path as, or "" if f
+// does not import it. def is the name of the package.
+func importName(f *ast.File, path, def string) string {
+	for _, imp := range f.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || p != path {
+			continue
+		}
+
+		if imp.Name == nil {
+			return def
+		}
+
+		// Blank and dot imports cannot be referred to by name.
+		if imp.Name.Name == "_" || imp.Name.Name == "." {
+			return ""
+		}
+
+		return imp.Name.Name
+	}
+
+	return ""
+}
+
+// isLoggingFunc reports whether sel, as resolved by info, is a function
+// of slog or a method of *slog.Logger, possibly promoted through
+// an embedded field.
+func isLoggingFunc(info *types.Info, sel *ast.SelectorExpr) bool {
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != ImportPath {
+		return false
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return true
+	}
+
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+
+	named, ok := ptr.Elem().(*types.Named)
+
+	return ok && named.Obj().Name() == "Logger"
+}
+
+func isSelector(e ast.Expr, pkg, name string) bool {
+	sel, ok := e.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+
+	id, ok := sel.X.(*ast.Ident)
+
+	return ok && pkg != "" && id.Name == pkg
+}
+
+func isCall(e ast.Expr, pkg, name string) bool {
+	call, ok := e.(*ast.CallExpr)
+
+	return ok && isSelector(call.Fun, pkg, name)
+}
@@ -0,0 +1,161 @@
+package slogvet
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var (
+	importerMu sync.Mutex
+	// imp imports packages from the export data that the go command
+	// builds, once for all tests.
+	imp = importer.ForCompiler(token.NewFileSet(), "gc", func(path string) (io.ReadCloser, error) {
+		out, err := exec.Command("go", "list", "-export", "-f", "{{.Export}}", path).Output()
+		if err != nil {
+			return nil, err
+		}
+
+		return os.Open(strings.TrimSpace(string(out)))
+	})
+)
+
+// check type-checks f and returns its diagnostics.
+func check(t *testing.T, fset *token.FileSet, f *ast.File) []Diagnostic {
+	t.Helper()
+
+	importerMu.Lock()
+	defer importerMu.Unlock()
+
+	info := &types.Info{Uses: map[*ast.Ident]types.Object{}}
+	conf := types.Config{Importer: imp}
+	if _, err := conf.Check("p", fset, []*ast.File{f}, info); err != nil {
+		t.Fatal(err)
+	}
+
+	return CheckFile(fset, f, info)
+}
+
+func TestCheckFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		exp  []string
+	}{
+		{
+			name: "not imported",
+			src: `package p
+import "fmt"
+func f(l interface{ Info(interface{}) }) { l.Info(fmt.Sprintf("%d", 1)) }`,
+		},
+		{
+			name: "sprintf",
+			src: `package p
+import (
+	"context"
+	"fmt"
+	"github.com/safe-waters/slog"
+)
+func f(l *slog.Logger, ctx context.Context) {
+	slog.Info(fmt.Sprintf("user %d", 1))
+	l.Warnf(nil, fmt.Sprintf("user %d", 1))
+	l.InfoCtx(ctx, fmt.Sprintf("user %d", 1))
+	l.Log(slog.InfoLevel, nil, fmt.Sprintf("user %d", 1))
+	l.Infom("user %d", 1)
+	l.Info("user")
+	l.Infof(slog.Fields{"user": fmt.Sprintf("%d", 1)}, "login")
+}`,
+			exp: []string{
+				"p.go:8:12: message built with fmt.Sprintf: log its values as fields instead",
+				"p.go:9:15: message built with fmt.Sprintf: log its values as fields instead",
+				"p.go:10:17: message built with fmt.Sprintf: log its values as fields instead",
+				"p.go:11:29: message built with fmt.Sprintf: log its values as fields instead",
+			},
+		},
+		{
+			name: "receivers",
+			src: `package p
+import (
+	"context"
+	"fmt"
+	"github.com/safe-waters/slog"
+)
+type other struct{}
+func (other) Info(msg interface{}) {}
+type wrapper struct{ *slog.Logger }
+func f(l *slog.Logger, o other, w wrapper, ctx context.Context) {
+	o.Info(fmt.Sprintf("user %d", 1))
+	w.Info(fmt.Sprintf("user %d", 1))
+	l.LogCtx(ctx, slog.InfoLevel, nil, fmt.Sprintf("user %d", 1))
+	slog.LogCtx(ctx, slog.InfoLevel, nil, fmt.Sprintf("user %d", 1))
+	l.LogDepth(1, slog.InfoLevel, fmt.Sprintf("user %d", 1))
+}`,
+			exp: []string{
+				"p.go:12:9: message built with fmt.Sprintf: log its values as fields instead",
+				"p.go:13:37: message built with fmt.Sprintf: log its values as fields instead",
+				"p.go:14:40: message built with fmt.Sprintf: log its values as fields instead",
+				"p.go:15:32: message built with fmt.Sprintf: log its values as fields instead",
+			},
+		},
+		{
+			name: "renamed imports",
+			src: `package p
+import (
+	f "fmt"
+	log "github.com/safe-waters/slog"
+)
+var _ = log.Fields{"level": "x", "": "y", "ok": "z"}
+func g() { log.Error(f.Sprintf("%d", 1)) }`,
+			exp: []string{
+				`p.go:6:20: field key "level" is reserved by slog`,
+				"p.go:6:34: field with an empty key",
+				"p.go:7:22: message built with fmt.Sprintf: log its values as fields instead",
+			},
+		},
+		{
+			name: "reserved keys",
+			src: `package p
+import "github.com/safe-waters/slog"
+var _ = slog.Fields{"message": 1, "user": slog.Fields{"time": 2, "": 3}}
+var _ = map[string]interface{}{"message": 1}`,
+			exp: []string{
+				`p.go:3:21: field key "message" is reserved by slog`,
+				"p.go:3:66: field with an empty key",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "p.go", test.src, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, d := range check(t, fset, f) {
+				got = append(got, d.String())
+			}
+
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Fatalf("expected diagnostics '%q', got '%q'", test.exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slog
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// callerStack returns the stack of the calling goroutine, skipping
+// skip frames, with one "function file:line" string per frame.
+func callerStack(skip int) []string {
+	pcs := make([]uintptr, maxStackFrames)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	stack := make([]string, 0, n)
+	for {
+		fr, more := frames.Next()
+		stack = append(stack, fmt.Sprintf("%s %s:%d", fr.Function, fr.File, fr.Line))
+
+		if !more {
+			return stack
+		}
+	}
+}
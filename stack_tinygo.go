@@ -0,0 +1,10 @@
+//go:build tinygo
+// +build tinygo
+
+package slog
+
+// callerStack returns nil, because TinyGo does not support
+// walking the stack.
+func callerStack(skip int) []string {
+	return nil
+}
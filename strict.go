@@ -0,0 +1,73 @@
+package slog
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// strictTesting is 1 once StrictTesting is called.
+var strictTesting int32
+
+// StrictTesting makes every Logger strict, as if it was created with
+// WithStrictTesting. It is intended to be called from TestMain, so
+// that misuse of loggers fails tests instead of going unnoticed.
+func StrictTesting() {
+	atomic.StoreInt32(&strictTesting, 1)
+}
+
+// WithStrictTesting makes the Logger panic on misuse instead of
+// silently coercing it, which is intended for tests. Misuse includes
+// fields with empty keys, fields whose keys collide with the Logger's
+// permanent fields, which would replace them, keys without values and
+// keys that are not strings given to methods such as Infow, and
+// logging after the Logger is closed.
+func WithStrictTesting() Option {
+	return func(l *Logger) {
+		l.strict = true
+	}
+}
+
+// isStrict reports whether the Logger panics on misuse.
+func (l *Logger) isStrict() bool {
+	return l.strict || atomic.LoadInt32(&strictTesting) == 1
+}
+
+// checkMisuse panics if f misuses the Logger,
+// or if the Logger is closed.
+func (l *Logger) checkMisuse(f Fields) {
+	if l.lc.isClosed() {
+		panic("slog: strict testing: logging after Close")
+	}
+
+	if err := checkKeys(f); err != nil {
+		panic("slog: strict testing: " + err.Error())
+	}
+
+	for k := range f {
+		if _, ok := l.permanentFields[k]; ok {
+			panic(fmt.Sprintf("slog: strict testing: field %q collides with a permanent field", k))
+		}
+	}
+}
+
+func checkKeys(f map[string]interface{}) error {
+	for k, v := range f {
+		if k == "" {
+			return fmt.Errorf("field with an empty key")
+		}
+
+		var g map[string]interface{}
+		switch v := v.(type) {
+		case Fields:
+			g = v
+		case map[string]interface{}:
+			g = v
+		}
+
+		if err := checkKeys(g); err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package slog
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestStrictTesting(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		f    Fields
+		exp  string
+	}{
+		{name: "valid", f: Fields{"a": "b", "group": Fields{"c": "d"}}},
+		{name: "empty key", f: Fields{"": "b"}, exp: "empty key"},
+		{name: "nested empty key", f: Fields{"group": Fields{"": "b"}}, exp: "group: field with an empty key"},
+		{name: "collision", f: Fields{"service": "other"}, exp: `"service" collides`},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mw := &mockWriter{}
+			l := NewLogger(WithOutput(mw), WithFields(Fields{"service": "api"}), WithStrictTesting())
+
+			// Misuse panics even when the event is discarded.
+			l.SetLevel(ErrorLevel)
+
+			defer func() {
+				r := recover()
+				if test.exp == "" {
+					if r != nil {
+						t.Fatalf("expected no panic, got '%v'", r)
+					}
+					return
+				}
+
+				if s, _ := r.(string); !strings.Contains(s, test.exp) {
+					t.Fatalf("expected a panic containing '%s', got '%v'", test.exp, r)
+				}
+			}()
+
+			l.Infof(test.f, "hello")
+		})
+	}
+}
+
+// TestStrictTestingGlobal is not parallel, because it changes
+// the mode of every Logger.
+func TestStrictTestingGlobal(t *testing.T) {
+	l := NewLogger(WithOutput(&mockWriter{}))
+	if l.isStrict() {
+		t.Fatal("expected the Logger not to be strict, but it was")
+	}
+
+	StrictTesting()
+	defer atomic.StoreInt32(&strictTesting, 0)
+
+	if !l.isStrict() {
+		t.Fatal("expected the Logger to be strict, but it was not")
+	}
+}
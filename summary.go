@@ -0,0 +1,107 @@
+package slog
+
+import (
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+const (
+	// maxSummaryMessages bounds the number of distinct messages
+	// a Logger counts for its Report.
+	maxSummaryMessages = 1000
+
+	// summaryTopMessages is the number of most repeated messages
+	// included in a Report.
+	summaryTopMessages = 5
+)
+
+// Report is an end-of-run summary of what a Logger has logged.
+type Report struct {
+	// Start is when the Logger was created.
+	Start time.Time `json:"start"`
+	// Duration is the time elapsed since Start.
+	Duration time.Duration `json:"duration"`
+	// Counts holds the number of events logged per level.
+	Counts map[string]int `json:"counts"`
+	// TopMessages holds the most repeated messages,
+	// in descending order of count.
+	TopMessages []MessageCount `json:"top_messages"`
+}
+
+// MessageCount is the number of times a message was logged.
+type MessageCount struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// Summary calls the default Logger's Summary method.
+func Summary() Report {
+	return defaultLogger.Summary()
+}
+
+// PrintSummary calls the default Logger's PrintSummary method.
+func PrintSummary(w io.Writer) error {
+	return defaultLogger.PrintSummary(w)
+}
+
+// Summary returns a Report of the events logged so far by the Logger,
+// the Logger it was derived from, and the Loggers derived from either
+// with Named or With, which share one Report.
+//
+// Only the first 1000 distinct messages are counted
+// toward TopMessages, so memory stays bounded in
+// long-running processes.
+func (l *Logger) Summary() Report {
+	rec := l.rec
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	r := Report{
+		Start:    rec.start,
+		Duration: time.Since(rec.start),
+		Counts:   make(map[string]int, len(rec.counts)),
+	}
+
+	for lv, n := range rec.counts {
+		r.Counts[lv] = n
+	}
+
+	for msg, n := range rec.messages {
+		r.TopMessages = append(r.TopMessages, MessageCount{Message: msg, Count: n})
+	}
+
+	sort.Slice(r.TopMessages, func(i, j int) bool {
+		if r.TopMessages[i].Count != r.TopMessages[j].Count {
+			return r.TopMessages[i].Count > r.TopMessages[j].Count
+		}
+		return r.TopMessages[i].Message < r.TopMessages[j].Message
+	})
+
+	if len(r.TopMessages) > summaryTopMessages {
+		r.TopMessages = r.TopMessages[:summaryTopMessages]
+	}
+
+	return r
+}
+
+// PrintSummary writes the Logger's Summary to w as a single line of JSON.
+// It is intended to be deferred in main, for example:
+//
+//	defer slog.PrintSummary(os.Stderr)
+//
+// If w is nil, it will default to os.Stdout.
+func (l *Logger) PrintSummary(w io.Writer) error {
+	if w == nil {
+		w = os.Stdout
+	}
+
+	byt, err := marshalReport(l.Summary())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(byt, '\n'))
+	return err
+}
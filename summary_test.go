@@ -0,0 +1,73 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	l := NewLogger(WithOutput(&mockWriter{}))
+
+	for i := 0; i < 3; i++ {
+		l.Info("retrying")
+	}
+	l.Info("started")
+	l.Warn("slow")
+	l.Error("failed")
+
+	r := l.Summary()
+
+	expCounts := map[string]int{"info": 4, "warn": 1, "error": 1}
+	if len(expCounts) != len(r.Counts) {
+		t.Fatalf("expected '%d' Level(s), got '%d'", len(expCounts), len(r.Counts))
+	}
+
+	for lv, n := range expCounts {
+		if r.Counts[lv] != n {
+			t.Fatalf("expected '%d' %s event(s), got '%d'", n, lv, r.Counts[lv])
+		}
+	}
+
+	if len(r.TopMessages) != 4 {
+		t.Fatalf("expected '%d' top message(s), got '%d'", 4, len(r.TopMessages))
+	}
+
+	top := r.TopMessages[0]
+	if top.Message != "retrying" || top.Count != 3 {
+		t.Fatalf("expected top message 'retrying' x3, got '%s' x%d", top.Message, top.Count)
+	}
+
+	if r.Duration <= 0 {
+		t.Fatalf("expected positive duration, got '%s'", r.Duration)
+	}
+}
+
+func TestPrintSummary(t *testing.T) {
+	t.Parallel()
+
+	l := NewLogger(WithOutput(&mockWriter{}))
+	l.Error("failed")
+
+	var buf bytes.Buffer
+	if err := l.PrintSummary(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, k := range []string{"start", "duration", "counts", "top_messages"} {
+		if _, ok := raw[k]; !ok {
+			t.Fatalf("expected key '%s' but it did not exist", k)
+		}
+	}
+
+	if _, ok := raw["duration"].(string); !ok {
+		t.Fatalf("expected duration to be a string, got '%v'", raw["duration"])
+	}
+}
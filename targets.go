@@ -0,0 +1,157 @@
+package slog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Targets is a store of rules that lower the minimum level of the events
+// whose fields match them, so that support engineers can turn on verbose
+// logging for one affected user or tenant only, without flooding the logs
+// with everyone else's events. Rules expire after a while, so that they
+// are not forgotten. A Targets is safe for concurrent use and is shared by
+// the Loggers created with WithTargets and those derived from them:
+//
+//	t := slog.NewTargets()
+//	l := slog.NewLogger(slog.WithTargets(t))
+//
+//	// Later, for example from an admin endpoint:
+//	t.Enable(slog.DebugLevel, slog.Fields{"user_id": "123"}, 30*time.Minute)
+//
+// When the store has no rules, checking it costs an atomic load.
+type Targets struct {
+	mu    sync.Mutex
+	rules atomic.Value // []target
+
+	// now, if non-nil, replaces time.Now.
+	now func() time.Time
+}
+
+// target is a rule of a Targets.
+type target struct {
+	level Level
+	where map[string]string
+	until time.Time
+}
+
+// NewTargets returns an empty Targets.
+func NewTargets() *Targets {
+	t := &Targets{}
+	t.rules.Store([]target(nil))
+
+	return t
+}
+
+// WithTargets makes the Logger log the events that match the rules
+// of t at their levels, even if they are less severe than the minimum
+// level of the Logger. An event matches a rule if each of the rule's
+// fields is a field of the event, or a permanent field of the Logger,
+// with the same value, once both are formatted.
+func WithTargets(t *Targets) Option {
+	return func(l *Logger) {
+		l.targets = t
+	}
+}
+
+// Enable adds a rule that logs the events whose fields match where at lv
+// and more severe levels, until d has passed. A rule with no fields
+// matches every event.
+func (t *Targets) Enable(lv Level, where Fields, d time.Duration) {
+	w := make(map[string]string, len(where))
+	for k, v := range where {
+		w[k] = formatValue(v)
+	}
+
+	t.mu.Lock()
+	now := t.time()
+	rules := []target{{level: lv, where: w, until: now.Add(d)}}
+	for _, r := range t.load() {
+		if now.Before(r.until) {
+			rules = append(rules, r)
+		}
+	}
+
+	t.rules.Store(rules)
+	t.mu.Unlock()
+
+	notifyChange(t, "targets", len(rules)-1, len(rules))
+}
+
+// Clear removes every rule.
+func (t *Targets) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rules.Store([]target(nil))
+}
+
+// Len returns the number of rules that have not expired.
+func (t *Targets) Len() int {
+	now := t.time()
+
+	n := 0
+	for _, r := range t.load() {
+		if now.Before(r.until) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (t *Targets) load() []target {
+	rules, _ := t.rules.Load().([]target)
+
+	return rules
+}
+
+func (t *Targets) time() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+
+	return time.Now()
+}
+
+// targeted reports whether an event at lv with fields f or fs
+// matches a rule of the Logger's Targets, if any.
+func (l *Logger) targeted(lv Level, f Fields, fs []Field) bool {
+	if l.targets == nil {
+		return false
+	}
+
+	rules := l.targets.load()
+	if len(rules) == 0 {
+		return false
+	}
+
+	now := l.targets.time()
+
+rules:
+	for _, r := range rules {
+		if !now.Before(r.until) || !atLeast(lv, r.level) {
+			continue
+		}
+
+		for k, v := range r.where {
+			ev, ok := f[k]
+			for i := len(fs) - 1; !ok && i >= 0; i-- {
+				if fs[i].Key == k {
+					ev, ok = fs[i].Value(), true
+				}
+			}
+			if !ok {
+				ev, ok = l.permanentFields[k]
+			}
+
+			if !ok || formatValue(ev) != v {
+				continue rules
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
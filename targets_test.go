@@ -0,0 +1,89 @@
+package slog
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTargets(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	targets := NewTargets()
+	targets.now = func() time.Time { return now }
+
+	w := &linesWriter{}
+	l := NewLogger(WithOutput(w), WithTargets(targets))
+	l.SetLevel(WarnLevel)
+
+	acme := l.With(Fields{"tenant": "acme"})
+
+	log := func() {
+		l.Debugf(Fields{"user_id": "123"}, "user 123")
+		l.Debugf(Fields{"user_id": 123}, "user 123 as int")
+		l.Debugf(Fields{"user_id": "456"}, "user 456")
+		l.Tracef(Fields{"user_id": "123"}, "user 123 at trace")
+		acme.Debug("acme")
+		l.DebugCtx(context.Background(), "no fields")
+	}
+
+	messages := func() []string {
+		defer func() { w.lines = nil }()
+
+		var ms []string
+		for _, line := range w.lines {
+			var e event
+			if err := json.Unmarshal([]byte(line), &e); err != nil {
+				t.Fatal(err)
+			}
+			ms = append(ms, e.Message.(string))
+		}
+
+		return ms
+	}
+
+	expect := func(exp ...string) {
+		t.Helper()
+
+		got := messages()
+		if len(got) != len(exp) {
+			t.Fatalf("expected '%v', got '%v'", exp, got)
+		}
+
+		for i := range exp {
+			if got[i] != exp[i] {
+				t.Fatalf("expected '%v', got '%v'", exp, got)
+			}
+		}
+	}
+
+	log()
+	expect()
+
+	targets.Enable(DebugLevel, Fields{"user_id": "123"}, 30*time.Minute)
+	targets.Enable(DebugLevel, Fields{"tenant": "acme"}, time.Hour)
+
+	if n := targets.Len(); n != 2 {
+		t.Fatalf("expected '%d' rule(s), got '%d'", 2, n)
+	}
+
+	log()
+	expect("user 123", "user 123 as int", "acme")
+
+	now = now.Add(30 * time.Minute)
+
+	if n := targets.Len(); n != 1 {
+		t.Fatalf("expected '%d' rule(s), got '%d'", 1, n)
+	}
+
+	log()
+	expect("acme")
+
+	targets.Clear()
+
+	log()
+	expect()
+}